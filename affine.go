@@ -0,0 +1,164 @@
+package loc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// An Affine is a 2D affine transform that maps (x, y) to
+// (A*x + C*y + E, B*x + D*y + F). The coefficients are float64 whatever S is;
+// results are rounded to the nearest value for integer S.
+// The zero Affine maps every point to the origin; use Identity instead.
+type Affine[S ng.Scalar] struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the transform that leaves points unchanged.
+func Identity[S ng.Scalar]() Affine[S] {
+	return Affine[S]{A: 1, D: 1}
+}
+
+// Translation returns the transform that translates by p.
+func Translation[S ng.Scalar](p Point[S]) Affine[S] {
+	return Affine[S]{A: 1, D: 1, E: float64(p.X), F: float64(p.Y)}
+}
+
+// Scaling returns the transform that scales by sx and sy about the origin.
+func Scaling[S ng.Scalar](sx, sy float64) Affine[S] {
+	return Affine[S]{A: sx, D: sy}
+}
+
+// Rotation returns the transform that rotates by rad radians about the origin.
+// Positive angles rotate X toward Y, which is clockwise on screen.
+func Rotation[S ng.Scalar](rad float64) Affine[S] {
+	sin, cos := math.Sincos(rad)
+	return Affine[S]{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Skewing returns the transform that skews by ax radians along X and ay
+// radians along Y.
+func Skewing[S ng.Scalar](ax, ay float64) Affine[S] {
+	return Affine[S]{A: 1, B: math.Tan(ay), C: math.Tan(ax), D: 1}
+}
+
+// String returns a string representation of a like "[1 0 0 1 0 0]".
+func (a Affine[S]) String() string {
+	return fmt.Sprint([]float64{a.A, a.B, a.C, a.D, a.E, a.F})
+}
+
+// Then returns the transform that applies a and then b.
+func (a Affine[S]) Then(b Affine[S]) Affine[S] {
+	return Affine[S]{
+		A: b.A*a.A + b.C*a.B,
+		B: b.B*a.A + b.D*a.B,
+		C: b.A*a.C + b.C*a.D,
+		D: b.B*a.C + b.D*a.D,
+		E: b.A*a.E + b.C*a.F + b.E,
+		F: b.B*a.E + b.D*a.F + b.F,
+	}
+}
+
+// Translate returns a followed by a translation by p.
+func (a Affine[S]) Translate(p Point[S]) Affine[S] {
+	return a.Then(Translation(p))
+}
+
+// Scale returns a followed by a scaling by sx and sy.
+func (a Affine[S]) Scale(sx, sy float64) Affine[S] {
+	return a.Then(Scaling[S](sx, sy))
+}
+
+// Rotate returns a followed by a rotation by rad radians.
+func (a Affine[S]) Rotate(rad float64) Affine[S] {
+	return a.Then(Rotation[S](rad))
+}
+
+// Skew returns a followed by a skew by ax and ay radians.
+func (a Affine[S]) Skew(ax, ay float64) Affine[S] {
+	return a.Then(Skewing[S](ax, ay))
+}
+
+// About returns the transform that applies a about the point p instead of
+// the origin.
+func (a Affine[S]) About(p Point[S]) Affine[S] {
+	x, y := float64(p.X), float64(p.Y)
+	return Affine[S]{A: 1, D: 1, E: -x, F: -y}.Then(a).Then(Affine[S]{A: 1, D: 1, E: x, F: y})
+}
+
+// Det returns the determinant of the linear part of a.
+func (a Affine[S]) Det() float64 {
+	return a.A*a.D - a.B*a.C
+}
+
+// Invert returns the inverse of a and reports whether a is invertible.
+func (a Affine[S]) Invert() (Affine[S], bool) {
+	det := a.Det()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Affine[S]{}, false
+	}
+	return Affine[S]{
+		A: a.D / det,
+		B: -a.B / det,
+		C: -a.C / det,
+		D: a.A / det,
+		E: (a.C*a.F - a.D*a.E) / det,
+		F: (a.B*a.E - a.A*a.F) / det,
+	}, true
+}
+
+// Apply transforms the coordinates (x, y).
+func (a Affine[S]) Apply(x, y float64) (float64, float64) {
+	return a.A*x + a.C*y + a.E, a.B*x + a.D*y + a.F
+}
+
+// ApplyPoint returns the point p transformed by a.
+func (a Affine[S]) ApplyPoint(p Point[S]) Point[S] {
+	x, y := a.Apply(float64(p.X), float64(p.Y))
+	return Point[S]{X: roundS[S](x), Y: roundS[S](y)}
+}
+
+// ApplyQuad returns the four corners of r transformed by a, in the order
+// Min, (Max.X, Min.Y), Max, (Min.X, Max.Y).
+func (a Affine[S]) ApplyQuad(r Rect[S]) [4]Point[S] {
+	return [4]Point[S]{
+		a.ApplyPoint(r.Min),
+		a.ApplyPoint(Xy(r.Max.X, r.Min.Y)),
+		a.ApplyPoint(r.Max),
+		a.ApplyPoint(Xy(r.Min.X, r.Max.Y)),
+	}
+}
+
+// ApplyRect returns the bounding box of r transformed by a.
+// For integer S the box is rounded outward.
+func (a Affine[S]) ApplyRect(r Rect[S]) Rect[S] {
+	x0, y0 := math.Inf(1), math.Inf(1)
+	x1, y1 := math.Inf(-1), math.Inf(-1)
+	for _, c := range [][2]S{{r.Min.X, r.Min.Y}, {r.Max.X, r.Min.Y}, {r.Max.X, r.Max.Y}, {r.Min.X, r.Max.Y}} {
+		x, y := a.Apply(float64(c[0]), float64(c[1]))
+		x0, y0 = min(x0, x), min(y0, y)
+		x1, y1 = max(x1, x), max(y1, y)
+	}
+	return Xyxy(
+		floorS[S](snap(x0)), floorS[S](snap(y0)),
+		ceilS[S](snap(x1)), ceilS[S](snap(y1)),
+	)
+}
+
+// snap rounds f to the nearest integer if it is within rounding error of it,
+// so that outward rounding does not grow results by a whole unit.
+func snap(f float64) float64 {
+	if r := math.Round(f); math.Abs(f-r) < 1e-9 {
+		return r
+	}
+	return f
+}
+
+// ceilS converts f to S, rounding up for integer S.
+func ceilS[S ng.Scalar](f float64) S {
+	if isInt[S]() {
+		return S(math.Ceil(f))
+	}
+	return S(f)
+}
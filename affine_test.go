@@ -0,0 +1,67 @@
+package loc_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+	"github.com/eihigh/ng"
+)
+
+func TestAffine_Rotate(t *testing.T) {
+	a := loc.Rotation[int](math.Pi / 2)
+	if got, want := a.ApplyPoint(loc.Xy(10, 0)), loc.Xy(0, 10); got != want {
+		t.Errorf("ApplyPoint mismatch, want %v, got %v", want, got)
+	}
+	r := loc.Xyxy(0, 0, 20, 10)
+	if got, want := a.ApplyRect(r), loc.Xyxy(-10, 0, 0, 20); got != want {
+		t.Errorf("ApplyRect mismatch, want %v, got %v", want, got)
+	}
+	want := [4]loc.Point[int]{loc.Xy(0, 0), loc.Xy(0, 20), loc.Xy(-10, 20), loc.Xy(-10, 0)}
+	if got := a.ApplyQuad(r); got != want {
+		t.Errorf("ApplyQuad mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestAffine_Compose(t *testing.T) {
+	a := loc.Identity[float64]().Scale(2, 3).Translate(loc.Xy(5.0, 7)).Rotate(0.3).Skew(0.1, 0.2)
+	inv, ok := a.Invert()
+	if !ok {
+		t.Fatalf("Invert should succeed")
+	}
+	p := loc.Xy(12.5, -4.25)
+	got := inv.ApplyPoint(a.ApplyPoint(p))
+	if math.Abs(got.X-p.X) > 1e-9 || math.Abs(got.Y-p.Y) > 1e-9 {
+		t.Errorf("Invert round trip mismatch, want %v, got %v", p, got)
+	}
+	if _, ok := loc.Scaling[float64](0, 1).Invert(); ok {
+		t.Errorf("Invert of a singular transform should fail")
+	}
+}
+
+func TestAffine_About(t *testing.T) {
+	r := loc.Xyxy(10, 10, 30, 20)
+	a := loc.Rotation[int](math.Pi).About(r.Center())
+	if got := a.ApplyRect(r); got != r {
+		t.Errorf("ApplyRect about center mismatch, want %v, got %v", r, got)
+	}
+	a = loc.Scaling[int](2, 2).About(r.Min)
+	if got, want := a.ApplyRect(r), loc.Xyxy(10, 10, 50, 30); got != want {
+		t.Errorf("ApplyRect scale about min mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestPoint_Conversions(t *testing.T) {
+	p := loc.AsPoint[int](image.Pt(3, 4))
+	if p != loc.Xy(3, 4) {
+		t.Errorf("AsPoint(image.Point) mismatch, got %v", p)
+	}
+	v := loc.Xy(1.5, 2.5).Vec2()
+	if v != (ng.Vec2[float64]{X: 1.5, Y: 2.5}) {
+		t.Errorf("Vec2 mismatch, got %v", v)
+	}
+	if loc.AsPoint[float64](v) != loc.Xy(1.5, 2.5) {
+		t.Errorf("AsPoint(ng.Vec2) mismatch")
+	}
+}
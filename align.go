@@ -24,16 +24,13 @@ func (r Rect[S]) Center() Point[S] {
 // Align returns a new rectangle with the same size as r,
 // where the point p is at the relative position (rx, ry) within the new rectangle.
 func (p Point[S]) Align(r Rect[S], rx, ry float64) Rect[S] {
-	return Rect[S]{
-		Min: Point[S]{
-			X: p.X - rel(r.Dx(), rx),
-			Y: p.Y - rel(r.Dy(), ry),
-		},
-		Max: Point[S]{
-			X: p.X + rel(r.Dx(), 1-rx),
-			Y: p.Y + rel(r.Dy(), 1-ry),
-		},
+	// Max is derived from Min so that truncation in rel cannot lose a unit
+	// of an odd integer size.
+	min := Point[S]{
+		X: p.X - rel(r.Dx(), rx),
+		Y: p.Y - rel(r.Dy(), ry),
 	}
+	return Rect[S]{Min: min, Max: min.Add(r.Size())}
 }
 
 // AlignCenter returns a new rectangle with the same size as r,
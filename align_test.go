@@ -307,3 +307,15 @@ func TestRect_RepeatY_NegativeN(t *testing.T) {
 		t.Errorf("RepeatY(-2, 10) overall should be empty, got %v", gotOverall)
 	}
 }
+
+func TestPoint_Align_OddSize(t *testing.T) {
+	box := loc.Xyxy(0, 0, 101, 7)
+	got := loc.Xy(50, 50).Align(box, 0.5, 0.5)
+	want := loc.Xyxy(0, 47, 101, 54)
+	if !want.Eq(got) {
+		t.Errorf("Align odd size mismatch, want %v, got %v", want, got)
+	}
+	if got.Size() != box.Size() {
+		t.Errorf("Align should keep the size %v, got %v", box.Size(), got.Size())
+	}
+}
@@ -0,0 +1,165 @@
+package loc
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// An Atlas allocates rectangles from a page at runtime, as needed by glyph
+// caches. Space is carved with guillotine cuts (Rect.CutX and Rect.CutY) and
+// freed space is coalesced with adjacent free space when the two form a
+// rectangle.
+type Atlas[S ng.Scalar] struct {
+	page  Rect[S]
+	g     guillotine[S]
+	alloc []Rect[S]
+}
+
+// AtlasStats describes the space of an Atlas.
+type AtlasStats[S ng.Scalar] struct {
+	Allocs      int     // number of allocated rectangles
+	FreeRects   int     // number of free rectangles
+	UsedArea    S       // total allocated area
+	FreeArea    S       // total free area
+	LargestFree Rect[S] // largest free rectangle by area
+	// Fragmentation is 1 - LargestFree area / FreeArea. It is 0 when all free
+	// space is a single rectangle and approaches 1 as free space scatters.
+	Fragmentation float64
+}
+
+// An AtlasMove records that the allocation From was moved to To.
+type AtlasMove[S ng.Scalar] struct {
+	From, To Rect[S]
+}
+
+// NewAtlas returns an empty atlas over page.
+func NewAtlas[S ng.Scalar](page Rect[S]) *Atlas[S] {
+	a := &Atlas[S]{page: page}
+	a.Reset()
+	return a
+}
+
+// Page returns the page of a.
+func (a *Atlas[S]) Page() Rect[S] {
+	return a.page
+}
+
+// Reset frees all allocations.
+func (a *Atlas[S]) Reset() {
+	a.g.free = []Rect[S]{a.page}
+	a.alloc = nil
+}
+
+// Alloc allocates a rectangle of the given size and reports whether there was
+// room for it. Sizes with a non-positive dimension are never allocated.
+func (a *Atlas[S]) Alloc(size Point[S]) (Rect[S], bool) {
+	if size.X <= 0 || size.Y <= 0 {
+		return Rect[S]{}, false
+	}
+	pos, _, ok := a.g.find(size.X, size.Y)
+	if !ok {
+		return Rect[S]{}, false
+	}
+	r := Rect[S]{Min: pos, Max: pos.Add(size)}
+	a.g.place(r)
+	a.alloc = append(a.alloc, r)
+	return r, true
+}
+
+// Free releases r, which must have been returned by Alloc, and reports
+// whether it was allocated.
+func (a *Atlas[S]) Free(r Rect[S]) bool {
+	i := slices.Index(a.alloc, r)
+	if i < 0 {
+		return false
+	}
+	a.alloc = slices.Delete(a.alloc, i, i+1)
+	a.g.free = append(a.g.free, r)
+	a.coalesce()
+	return true
+}
+
+// coalesce merges pairs of free rectangles sharing a whole edge until no
+// such pair remains.
+func (a *Atlas[S]) coalesce() {
+	free := a.g.free
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(free) && !merged; i++ {
+			for j := i + 1; j < len(free); j++ {
+				if u, ok := joinRects(free[i], free[j]); ok {
+					free[i] = u
+					free = slices.Delete(free, j, j+1)
+					merged = true
+					break
+				}
+			}
+		}
+	}
+	a.g.free = free
+}
+
+// joinRects returns the union of r and s if it is exactly covered by them.
+func joinRects[S ng.Scalar](r, s Rect[S]) (Rect[S], bool) {
+	if r.Min.Y == s.Min.Y && r.Max.Y == s.Max.Y && (r.Max.X == s.Min.X || s.Max.X == r.Min.X) ||
+		r.Min.X == s.Min.X && r.Max.X == s.Max.X && (r.Max.Y == s.Min.Y || s.Max.Y == r.Min.Y) {
+		return r.Union(s), true
+	}
+	return Rect[S]{}, false
+}
+
+// Allocs returns the allocated rectangles in allocation order.
+func (a *Atlas[S]) Allocs() []Rect[S] {
+	return slices.Clone(a.alloc)
+}
+
+// Stats returns statistics about the space of a.
+func (a *Atlas[S]) Stats() AtlasStats[S] {
+	st := AtlasStats[S]{Allocs: len(a.alloc), FreeRects: len(a.g.free)}
+	for _, r := range a.alloc {
+		st.UsedArea += r.Dx() * r.Dy()
+	}
+	for _, r := range a.g.free {
+		st.FreeArea += r.Dx() * r.Dy()
+		if area(r) > area(st.LargestFree) {
+			st.LargestFree = r
+		}
+	}
+	if st.FreeArea > 0 {
+		st.Fragmentation = 1 - area(st.LargestFree)/float64(st.FreeArea)
+	}
+	return st
+}
+
+// Compact reallocates every allocation from an empty page, largest first,
+// and returns the allocations that moved. If the allocations cannot all be
+// placed again, a is left unchanged and ok is false.
+func (a *Atlas[S]) Compact() (moves []AtlasMove[S], ok bool) {
+	old := slices.Clone(a.alloc)
+	oldFree := slices.Clone(a.g.free)
+
+	order := slices.Clone(old)
+	slices.SortStableFunc(order, func(r, s Rect[S]) int {
+		switch ar, as := area(r), area(s); {
+		case ar > as:
+			return -1
+		case ar < as:
+			return 1
+		}
+		return 0
+	})
+
+	a.Reset()
+	for _, r := range order {
+		to, ok := a.Alloc(r.Size())
+		if !ok {
+			a.alloc, a.g.free = old, oldFree
+			return nil, false
+		}
+		if to != r {
+			moves = append(moves, AtlasMove[S]{From: r, To: to})
+		}
+	}
+	return moves, true
+}
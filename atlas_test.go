@@ -0,0 +1,95 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestAtlas_AllocFree(t *testing.T) {
+	a := loc.NewAtlas(loc.Xyxy(0, 0, 100, 100))
+	var rects []loc.Rect[int]
+	for range 4 {
+		r, ok := a.Alloc(loc.Xy(50, 50))
+		if !ok {
+			t.Fatalf("Alloc(50x50) should succeed")
+		}
+		rects = append(rects, r)
+	}
+	if _, ok := a.Alloc(loc.Xy(1, 1)); ok {
+		t.Errorf("Alloc on a full page should fail")
+	}
+	for i, r := range rects {
+		for _, s := range rects[i+1:] {
+			if r.Overlaps(s) {
+				t.Errorf("allocations %v and %v overlap", r, s)
+			}
+		}
+	}
+	for _, r := range rects {
+		if !a.Free(r) {
+			t.Errorf("Free(%v) should succeed", r)
+		}
+	}
+	if a.Free(rects[0]) {
+		t.Errorf("double Free should fail")
+	}
+	st := a.Stats()
+	if st.FreeRects != 1 || st.LargestFree != loc.Xyxy(0, 0, 100, 100) || st.Fragmentation != 0 {
+		t.Errorf("freed space should coalesce into the page, got %+v", st)
+	}
+	if _, ok := a.Alloc(loc.Xy(100, 100)); !ok {
+		t.Errorf("Alloc(100x100) after freeing everything should succeed")
+	}
+}
+
+func TestAtlas_Compact(t *testing.T) {
+	a := loc.NewAtlas(loc.Xyxy(0, 0, 100, 100))
+	var keep []loc.Rect[int]
+	for i := range 10 {
+		r, ok := a.Alloc(loc.Xy(10, 100))
+		if !ok {
+			t.Fatalf("Alloc(10x100) should succeed")
+		}
+		if i%2 == 1 {
+			keep = append(keep, r)
+		}
+	}
+	for i, r := range a.Allocs() {
+		if i%2 == 0 {
+			a.Free(r)
+		}
+	}
+	before := a.Stats()
+	if before.Fragmentation == 0 {
+		t.Errorf("free space should be fragmented, got %+v", before)
+	}
+	if _, ok := a.Alloc(loc.Xy(20, 100)); ok {
+		t.Errorf("Alloc(20x100) should fail before compaction")
+	}
+
+	moves, ok := a.Compact()
+	if !ok {
+		t.Fatalf("Compact should succeed")
+	}
+	moved := map[loc.Rect[int]]loc.Rect[int]{}
+	for _, m := range moves {
+		moved[m.From] = m.To
+	}
+	for _, r := range keep {
+		to, ok := moved[r]
+		if !ok {
+			to = r
+		}
+		if to.Size() != r.Size() {
+			t.Errorf("move of %v changed size to %v", r, to)
+		}
+	}
+	after := a.Stats()
+	if after.UsedArea != before.UsedArea || after.Fragmentation != 0 {
+		t.Errorf("Compact stats mismatch, before %+v, after %+v", before, after)
+	}
+	if _, ok := a.Alloc(loc.Xy(50, 100)); !ok {
+		t.Errorf("Alloc(50x100) should succeed after compaction")
+	}
+}
@@ -0,0 +1,323 @@
+package loc
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/eihigh/ng"
+)
+
+// Arithmetic errors.
+var (
+	ErrOverflow       = errors.New("loc: arithmetic overflow")
+	ErrDivisionByZero = errors.New("loc: division by zero")
+)
+
+// limits returns the smallest and largest values of S. For floats these are
+// the infinities.
+func limits[S ng.Scalar]() (lo, hi S) {
+	if !isInt[S]() {
+		return S(math.Inf(-1)), S(math.Inf(1))
+	}
+	// Set one more low bit at a time until the value wraps around, which
+	// leaves every value bit set.
+	hi = 1
+	for next := hi*2 + 1; next > hi; next = hi*2 + 1 {
+		hi = next
+	}
+	return -hi - 1, hi // -hi-1 wraps to 0 for unsigned types
+}
+
+// addChecked returns a+b and reports whether it did not overflow.
+func addChecked[S ng.Scalar](a, b S) (S, bool) {
+	c := a + b
+	if !isInt[S]() {
+		return c, !math.IsInf(float64(c), 0)
+	}
+	return c, !(b > 0 && c < a || b < 0 && c > a)
+}
+
+// subChecked returns a-b and reports whether it did not overflow.
+func subChecked[S ng.Scalar](a, b S) (S, bool) {
+	c := a - b
+	if !isInt[S]() {
+		return c, !math.IsInf(float64(c), 0)
+	}
+	return c, !(b > 0 && c > a || b < 0 && c < a)
+}
+
+// mulChecked returns a*b and reports whether it did not overflow.
+func mulChecked[S ng.Scalar](a, b S) (S, bool) {
+	c := a * b
+	if !isInt[S]() {
+		return c, !math.IsInf(float64(c), 0)
+	}
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	if c/b != a || (a < 0) != (b < 0) != (c < 0) {
+		return c, false
+	}
+	return c, true
+}
+
+// saturate returns the limit of S in the direction of positive.
+func saturate[S ng.Scalar](positive bool) S {
+	lo, hi := limits[S]()
+	if positive {
+		return hi
+	}
+	return lo
+}
+
+func addSat[S ng.Scalar](a, b S) S {
+	if c, ok := addChecked(a, b); ok {
+		return c
+	}
+	return saturate[S](b > 0)
+}
+
+func mulSat[S ng.Scalar](a, b S) S {
+	if c, ok := mulChecked(a, b); ok {
+		return c
+	}
+	return saturate[S]((a < 0) == (b < 0))
+}
+
+// AddChecked returns the vector p+q, or ErrOverflow.
+func (p Point[S]) AddChecked(q Point[S]) (Point[S], error) {
+	x, okx := addChecked(p.X, q.X)
+	y, oky := addChecked(p.Y, q.Y)
+	if !okx || !oky {
+		return Point[S]{}, ErrOverflow
+	}
+	return Point[S]{X: x, Y: y}, nil
+}
+
+// AddSat returns the vector p+q, saturating at the limits of S.
+func (p Point[S]) AddSat(q Point[S]) Point[S] {
+	return Point[S]{X: addSat(p.X, q.X), Y: addSat(p.Y, q.Y)}
+}
+
+// MulChecked returns the vector p*k, or ErrOverflow.
+func (p Point[S]) MulChecked(k S) (Point[S], error) {
+	x, okx := mulChecked(p.X, k)
+	y, oky := mulChecked(p.Y, k)
+	if !okx || !oky {
+		return Point[S]{}, ErrOverflow
+	}
+	return Point[S]{X: x, Y: y}, nil
+}
+
+// MulSat returns the vector p*k, saturating at the limits of S.
+func (p Point[S]) MulSat(k S) Point[S] {
+	return Point[S]{X: mulSat(p.X, k), Y: mulSat(p.Y, k)}
+}
+
+// XywhChecked is like Xywh but returns ErrOverflow if x+w or y+h overflows.
+func XywhChecked[S ng.Scalar](x, y, w, h S) (Rect[S], error) {
+	max, err := Xy(x, y).AddChecked(Xy(w, h))
+	if err != nil {
+		return Rect[S]{}, err
+	}
+	return Rect[S]{Min: Xy(x, y), Max: max}, nil
+}
+
+// XywhSat is like Xywh but saturates x+w and y+h at the limits of S.
+func XywhSat[S ng.Scalar](x, y, w, h S) Rect[S] {
+	return Rect[S]{Min: Xy(x, y), Max: Xy(x, y).AddSat(Xy(w, h))}
+}
+
+// AddChecked returns the rectangle r translated by p, or ErrOverflow.
+func (r Rect[S]) AddChecked(p Point[S]) (Rect[S], error) {
+	min, err := r.Min.AddChecked(p)
+	if err != nil {
+		return Rect[S]{}, err
+	}
+	max, err := r.Max.AddChecked(p)
+	if err != nil {
+		return Rect[S]{}, err
+	}
+	return Rect[S]{Min: min, Max: max}, nil
+}
+
+// RepeatXChecked is like RepeatX but returns ErrOverflow instead of
+// wrapping around when the repeated rectangles exceed the range of S.
+func (r Rect[S]) RepeatXChecked(n int, gap S) ([]Rect[S], Rect[S], error) {
+	if _, err := repeatExtent(r.Min.X, r.Max.X, n, gap); err != nil {
+		return nil, Rect[S]{}, err
+	}
+	rects, all := r.RepeatX(n, gap)
+	return rects, all, nil
+}
+
+// RepeatYChecked is like RepeatY but returns ErrOverflow instead of
+// wrapping around when the repeated rectangles exceed the range of S.
+func (r Rect[S]) RepeatYChecked(n int, gap S) ([]Rect[S], Rect[S], error) {
+	if _, err := repeatExtent(r.Min.Y, r.Max.Y, n, gap); err != nil {
+		return nil, Rect[S]{}, err
+	}
+	rects, all := r.RepeatY(n, gap)
+	return rects, all, nil
+}
+
+// repeatExtent returns the end of the last of n copies of [lo, hi) separated
+// by gap, as laid out by RepeatX, checking the start and end of the last copy.
+// Those are the extreme coordinates: with a negative step the last start is
+// the lowest, and otherwise the last end is the highest.
+func repeatExtent[S ng.Scalar](lo, hi S, n int, gap S) (S, error) {
+	if n <= 0 {
+		return lo, nil
+	}
+	size, ok := subChecked(hi, lo)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	count, ok := toS[S](n - 1)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	step, ok := addChecked(size, gap)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	offset, ok := mulChecked(step, count)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	start, ok := addChecked(lo, offset)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	end, ok := addChecked(start, size)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return end, nil
+}
+
+// SplitXChecked is like SplitX but returns ErrOverflow if the width of r,
+// n, or the position of any item cannot be represented in S.
+func (r Rect[S]) SplitXChecked(n int, gap S) ([]Rect[S], error) {
+	if err := checkSplit(r.Min.X, r.Max.X, n, gap); err != nil {
+		return nil, err
+	}
+	return r.SplitX(n, gap), nil
+}
+
+// SplitYChecked is like SplitY but returns ErrOverflow if the height of r,
+// n, or the position of any item cannot be represented in S.
+func (r Rect[S]) SplitYChecked(n int, gap S) ([]Rect[S], error) {
+	if err := checkSplit(r.Min.Y, r.Max.Y, n, gap); err != nil {
+		return nil, err
+	}
+	return r.SplitY(n, gap), nil
+}
+
+// checkSplit replays the cursor of SplitX over [lo, hi), checking every item's
+// start and end. The cursor keeps advancing by gap after the items shrink to
+// zero width, so it can run past hi.
+func checkSplit[S ng.Scalar](lo, hi S, n int, gap S) error {
+	if n <= 1 {
+		return nil
+	}
+	size, ok := subChecked(hi, lo)
+	if !ok {
+		return ErrOverflow
+	}
+	count, ok := toS[S](n)
+	if !ok {
+		return ErrOverflow
+	}
+	gap = max(gap, 0)
+	gaps, ok := mulChecked(count-1, gap)
+	if !ok {
+		return ErrOverflow
+	}
+	var w S
+	if gaps < size {
+		w = (size - gaps) / count
+	}
+	step, ok := addChecked(w, gap)
+	if !ok {
+		return ErrOverflow
+	}
+	cursor := lo
+	for range n - 1 {
+		if _, ok := addChecked(cursor, w); !ok {
+			return ErrOverflow
+		}
+		if cursor, ok = addChecked(cursor, step); !ok {
+			return ErrOverflow
+		}
+	}
+	return nil
+}
+
+// toS converts n to S and reports whether it is represented exactly.
+func toS[S ng.Scalar](n int) (S, bool) {
+	s := S(n)
+	return s, int(s) == n && (s < 0) == (n < 0)
+}
+
+// RelExact returns length*num/den truncated toward zero. For integer S the
+// result is computed exactly, without the float64 rounding of ratios such as
+// those taken by Anchor, and ErrOverflow is returned if it does not fit S.
+func RelExact[S ng.Scalar](length, num, den S) (S, error) {
+	if den == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if !isInt[S]() {
+		return length * num / den, nil
+	}
+	neg := (length < 0) != (num < 0) != (den < 0)
+	hi, lo := bits.Mul64(abs64(length), abs64(num))
+	d := abs64(den)
+	if hi >= d {
+		return 0, ErrOverflow
+	}
+	q, _ := bits.Div64(hi, lo, d)
+
+	minS, maxS := limits[S]()
+	if neg {
+		if q > abs64(minS) {
+			return 0, ErrOverflow
+		}
+		return S(-int64(q)), nil // -2^63 wraps to itself, which is correct
+	}
+	if q > uint64(maxS) {
+		return 0, ErrOverflow
+	}
+	return S(q), nil
+}
+
+// abs64 returns the magnitude of an integer s.
+func abs64[S ng.Scalar](s S) uint64 {
+	if s < 0 {
+		return uint64(-int64(s))
+	}
+	return uint64(s)
+}
+
+// AnchorExact returns the point Min + (Dx*nx/den, Dy*ny/den) of r, computed
+// exactly as with RelExact.
+func (r Rect[S]) AnchorExact(nx, ny, den S) (Point[S], error) {
+	dx, ok := subChecked(r.Max.X, r.Min.X)
+	if !ok {
+		return Point[S]{}, ErrOverflow
+	}
+	dy, ok := subChecked(r.Max.Y, r.Min.Y)
+	if !ok {
+		return Point[S]{}, ErrOverflow
+	}
+	x, err := RelExact(dx, nx, den)
+	if err != nil {
+		return Point[S]{}, err
+	}
+	y, err := RelExact(dy, ny, den)
+	if err != nil {
+		return Point[S]{}, err
+	}
+	return r.Min.AddChecked(Xy(x, y))
+}
@@ -0,0 +1,146 @@
+package loc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestPoint_AddChecked(t *testing.T) {
+	if _, err := loc.Xy[int8](100, 0).AddChecked(loc.Xy[int8](28, 0)); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("int8 overflow not detected, got %v", err)
+	}
+	if got, err := loc.Xy[int8](100, -100).AddChecked(loc.Xy[int8](27, -28)); err != nil || got != loc.Xy[int8](127, -128) {
+		t.Errorf("AddChecked at limits mismatch, got %v, %v", got, err)
+	}
+	if _, err := loc.Xy[uint8](0, 200).AddChecked(loc.Xy[uint8](0, 56)); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("uint8 overflow not detected, got %v", err)
+	}
+	if got := loc.Xy[int8](100, -100).AddSat(loc.Xy[int8](100, -100)); got != loc.Xy[int8](127, -128) {
+		t.Errorf("AddSat mismatch, got %v", got)
+	}
+	if got := loc.Xy[uint16](60000, 2).AddSat(loc.Xy[uint16](60000, 2)); got != loc.Xy[uint16](math.MaxUint16, 4) {
+		t.Errorf("AddSat uint16 mismatch, got %v", got)
+	}
+}
+
+func TestPoint_MulChecked(t *testing.T) {
+	if _, err := loc.Xy[int64](math.MinInt64, 0).MulChecked(-1); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("MinInt64 * -1 not detected, got %v", err)
+	}
+	if _, err := loc.Xy[int32](1<<16, 1).MulChecked(1 << 15); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("int32 overflow not detected, got %v", err)
+	}
+	if got, err := loc.Xy[int32](-1<<15, 3).MulChecked(1 << 16); err != nil || got != loc.Xy[int32](math.MinInt32, 3<<16) {
+		t.Errorf("MulChecked mismatch, got %v, %v", got, err)
+	}
+	if got := loc.Xy[int8](-50, 50).MulSat(3); got != loc.Xy[int8](-128, 127) {
+		t.Errorf("MulSat mismatch, got %v", got)
+	}
+	if got := loc.Xy[int8](-50, 50).MulSat(-3); got != loc.Xy[int8](127, -128) {
+		t.Errorf("MulSat negative mismatch, got %v", got)
+	}
+}
+
+func TestXywhChecked(t *testing.T) {
+	if _, err := loc.XywhChecked[int16](30000, 0, 10000, 10); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("XywhChecked overflow not detected, got %v", err)
+	}
+	if got := loc.XywhSat[int16](30000, 0, 10000, 10); got != loc.Xyxy[int16](30000, 0, math.MaxInt16, 10) {
+		t.Errorf("XywhSat mismatch, got %v", got)
+	}
+	if _, err := loc.Xywh[int16](0, 0, 10, 10).AddChecked(loc.Xy[int16](32760, 0)); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("Rect.AddChecked overflow not detected, got %v", err)
+	}
+}
+
+func TestRect_RepeatXChecked(t *testing.T) {
+	r := loc.Xywh[int8](0, 0, 30, 10)
+	if _, _, err := r.RepeatXChecked(5, 2); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("RepeatXChecked overflow not detected, got %v", err)
+	}
+	rects, all, err := r.RepeatXChecked(3, 2)
+	if err != nil || len(rects) != 3 || all != loc.Xyxy[int8](0, 0, 94, 10) {
+		t.Errorf("RepeatXChecked mismatch, got %v, %v, %v", rects, all, err)
+	}
+	// With a negative gap the last end lies past lo+n*(size+gap).
+	if _, _, err := loc.Xyxy[int8](0, 0, 100, 1).RepeatXChecked(2, -50); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("RepeatXChecked negative gap overflow not detected, got %v", err)
+	}
+	if _, _, err := loc.Xyxy[int8](-100, 0, -90, 1).RepeatXChecked(3, -30); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("RepeatXChecked negative step overflow not detected, got %v", err)
+	}
+	rects, all, err = loc.Xyxy[int8](0, 0, 100, 1).RepeatXChecked(2, -80)
+	if err != nil || len(rects) != 2 || all != loc.Xyxy[int8](0, 0, 120, 1) {
+		t.Errorf("RepeatXChecked negative gap mismatch, got %v, %v, %v", rects, all, err)
+	}
+	if _, _, err := loc.Xywh[int8](0, 0, 10, 30).RepeatYChecked(5, 2); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("RepeatYChecked overflow not detected, got %v", err)
+	}
+}
+
+func TestRect_SplitXChecked(t *testing.T) {
+	if _, err := loc.Xyxy[int8](-100, 0, 100, 10).SplitXChecked(2, 0); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("width overflow not detected, got %v", err)
+	}
+	if _, err := loc.Xyxy[int8](0, 0, 100, 10).SplitXChecked(200, 0); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("count overflow not detected, got %v", err)
+	}
+	if _, err := loc.Xyxy[int8](0, 0, 100, 10).SplitXChecked(10, 20); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("gap overflow not detected, got %v", err)
+	}
+	// The items shrink to zero width, but the cursor keeps advancing by gap.
+	if _, err := loc.Xyxy[int8](100, 0, 120, 1).SplitXChecked(3, 50); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("cursor overflow not detected, got %v", err)
+	}
+	if _, err := loc.Xyxy[int8](0, 50, 10, 100).SplitYChecked(3, 60); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("SplitYChecked cursor overflow not detected, got %v", err)
+	}
+	if got, err := loc.Xyxy[int8](0, 0, 20, 1).SplitXChecked(3, 50); err != nil || len(got) != 3 {
+		t.Errorf("SplitXChecked within range mismatch, got %v, %v", got, err)
+	}
+	got, err := loc.Xyxy[int8](0, 0, 100, 10).SplitXChecked(4, 0)
+	if err != nil || len(got) != 4 || got[3].Max.X != 100 {
+		t.Errorf("SplitXChecked mismatch, got %v, %v", got, err)
+	}
+}
+
+func TestRelExact(t *testing.T) {
+	// float64 cannot represent this product or the ratio exactly.
+	const big = int64(1)<<62 + 1
+	got, err := loc.RelExact(big, 3, 4)
+	if want := big / 4 * 3; err != nil || got != want {
+		t.Errorf("RelExact mismatch, want %d, got %d, %v", want, got, err)
+	}
+	if got, err := loc.RelExact[int64](-7, 1, 2); err != nil || got != -3 {
+		t.Errorf("RelExact truncation mismatch, got %d, %v", got, err)
+	}
+	if got, err := loc.RelExact[int64](math.MinInt64, 1, 1); err != nil || got != math.MinInt64 {
+		t.Errorf("RelExact MinInt64 mismatch, got %d, %v", got, err)
+	}
+	if _, err := loc.RelExact[int64](math.MaxInt64, 2, 1); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("RelExact overflow not detected, got %v", err)
+	}
+	if _, err := loc.RelExact[uint8](200, 2, 3); err != nil {
+		t.Errorf("RelExact uint8 intermediate should not overflow, got %v", err)
+	}
+	if _, err := loc.RelExact[int](1, 1, 0); !errors.Is(err, loc.ErrDivisionByZero) {
+		t.Errorf("RelExact division by zero not detected, got %v", err)
+	}
+	if got, err := loc.RelExact(10.0, 1, 4); err != nil || got != 2.5 {
+		t.Errorf("RelExact float mismatch, got %v, %v", got, err)
+	}
+}
+
+func TestRect_AnchorExact(t *testing.T) {
+	r := loc.Xyxy[int64](0, 0, math.MaxInt64, 3)
+	got, err := r.AnchorExact(1, 1, 3)
+	if want := loc.Xy[int64](math.MaxInt64/3, 1); err != nil || got != want {
+		t.Errorf("AnchorExact mismatch, want %v, got %v, %v", want, got, err)
+	}
+	if _, err := loc.Xyxy[int64](math.MinInt64, 0, math.MaxInt64, 1).AnchorExact(1, 1, 2); !errors.Is(err, loc.ErrOverflow) {
+		t.Errorf("AnchorExact overflow not detected, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package loc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Circle is the open disk of points closer than Radius to Center. Like
+// half-open rectangles, circles that merely touch do not overlap, and a point
+// on the circumference is not contained.
+type Circle[S ng.Scalar] struct {
+	Center Point[S]
+	Radius S
+}
+
+// Circ is shorthand for Circle[S]{center, radius}.
+func Circ[S ng.Scalar](center Point[S], radius S) Circle[S] {
+	return Circle[S]{Center: center, Radius: radius}
+}
+
+// String returns a string representation of c like "(1,2)r3".
+func (c Circle[S]) String() string {
+	return fmt.Sprintf("%vr%v", c.Center, c.Radius)
+}
+
+// Bounds returns the smallest rectangle containing c.
+func (c Circle[S]) Bounds() Rect[S] {
+	return Rect[S]{
+		Min: Point[S]{X: c.Center.X - c.Radius, Y: c.Center.Y - c.Radius},
+		Max: Point[S]{X: c.Center.X + c.Radius, Y: c.Center.Y + c.Radius},
+	}
+}
+
+// Contains reports whether p is inside c.
+func (c Circle[S]) Contains(p Point[S]) bool {
+	d := p.Float64().Sub(c.Center.Float64())
+	r := float64(c.Radius)
+	return dot(d, d) < r*r
+}
+
+// Overlaps reports whether c and o have a non-empty intersection.
+func (c Circle[S]) Overlaps(o Circle[S]) bool {
+	d := o.Center.Float64().Sub(c.Center.Float64())
+	r := float64(c.Radius) + float64(o.Radius)
+	return c.Radius > 0 && o.Radius > 0 && dot(d, d) < r*r
+}
+
+// OverlapsRect reports whether c and r have a non-empty intersection.
+func (c Circle[S]) OverlapsRect(r Rect[S]) bool {
+	return c.capsule().OverlapsRect(r)
+}
+
+// Penetration returns the shortest vector by which c must move to stop
+// overlapping o, and false if they do not overlap. Circles with the same
+// center are separated along X. For integer types the vector is rounded away
+// from zero, so c.Add(v) never overlaps o.
+func (c Circle[S]) Penetration(o Circle[S]) (Point[S], bool) {
+	if !c.Overlaps(o) {
+		return Point[S]{}, false
+	}
+	d := c.Center.Float64().Sub(o.Center.Float64())
+	depth := float64(c.Radius) + float64(o.Radius)
+	n := Xy(1.0, 0.0)
+	if l := math.Hypot(d.X, d.Y); l > 0 {
+		depth -= l
+		n = Xy(d.X/l, d.Y/l)
+	}
+	return awayPoint[S](n.Mul(depth)), true
+}
+
+// PenetrationRect returns the shortest vector by which c must move to stop
+// overlapping r, and false if they do not overlap. For integer types the
+// vector is rounded away from zero.
+func (c Circle[S]) PenetrationRect(r Rect[S]) (Point[S], bool) {
+	return c.capsule().PenetrationRect(r)
+}
+
+// Add returns c translated by p.
+func (c Circle[S]) Add(p Point[S]) Circle[S] {
+	c.Center = c.Center.Add(p)
+	return c
+}
+
+func (c Circle[S]) capsule() Capsule[S] {
+	return Capsule[S]{A: c.Center, B: c.Center, Radius: c.Radius}
+}
+
+// Capsule is the open set of points closer than Radius to the segment from A
+// to B. A capsule with A == B is a circle.
+type Capsule[S ng.Scalar] struct {
+	A, B   Point[S]
+	Radius S
+}
+
+// String returns a string representation of c like "(1,2)-(3,4)r5".
+func (c Capsule[S]) String() string {
+	return fmt.Sprintf("%v-%vr%v", c.A, c.B, c.Radius)
+}
+
+// Segment returns the core segment of c.
+func (c Capsule[S]) Segment() Segment[S] {
+	return Segment[S]{A: c.A, B: c.B}
+}
+
+// Bounds returns the smallest rectangle containing c.
+func (c Capsule[S]) Bounds() Rect[S] {
+	b := c.Segment().Bounds()
+	return Rect[S]{
+		Min: Point[S]{X: b.Min.X - c.Radius, Y: b.Min.Y - c.Radius},
+		Max: Point[S]{X: b.Max.X + c.Radius, Y: b.Max.Y + c.Radius},
+	}
+}
+
+// Contains reports whether p is inside c.
+func (c Capsule[S]) Contains(p Point[S]) bool {
+	pf := p.Float64()
+	d := pf.Sub(Segment[float64]{A: c.A.Float64(), B: c.B.Float64()}.closest(pf))
+	r := float64(c.Radius)
+	return dot(d, d) < r*r
+}
+
+// Add returns c translated by p.
+func (c Capsule[S]) Add(p Point[S]) Capsule[S] {
+	c.A, c.B = c.A.Add(p), c.B.Add(p)
+	return c
+}
+
+// OverlapsRect reports whether c and r have a non-empty intersection.
+func (c Capsule[S]) OverlapsRect(r Rect[S]) bool {
+	_, ok := c.penetrationRect(r)
+	return ok
+}
+
+// PenetrationRect returns the shortest vector by which c must move to stop
+// overlapping r, and false if they do not overlap. For integer types the
+// vector is rounded away from zero, so c.Add(v) never overlaps r.
+func (c Capsule[S]) PenetrationRect(r Rect[S]) (Point[S], bool) {
+	v, ok := c.penetrationRect(r)
+	if !ok {
+		return Point[S]{}, false
+	}
+	return awayPoint[S](v), true
+}
+
+// penetrationRect finds the minimum translation vector between c and r with
+// the separating axis theorem. Besides the axes of r and the normal of the
+// core segment, the candidate axes include the direction from each corner of r
+// to its closest point on the segment, which covers the rounded ends.
+func (c Capsule[S]) penetrationRect(r Rect[S]) (Point[float64], bool) {
+	if r.Empty() || c.Radius <= 0 {
+		return Point[float64]{}, false
+	}
+	seg := Segment[float64]{A: c.A.Float64(), B: c.B.Float64()}
+	rad := float64(c.Radius)
+	corners := r.Polygon()
+
+	axes := []Point[float64]{{X: 1}, {Y: 1}}
+	if d := seg.B.Sub(seg.A); d != (Point[float64]{}) {
+		axes = append(axes, unit(Xy(-d.Y, d.X)))
+	}
+	for _, k := range corners {
+		kf := k.Float64()
+		if d := seg.closest(kf).Sub(kf); d != (Point[float64]{}) {
+			axes = append(axes, unit(d))
+		}
+	}
+
+	best, depth := Point[float64]{}, math.Inf(1)
+	for _, n := range axes {
+		cmin, cmax := math.Min(dot(seg.A, n), dot(seg.B, n))-rad, math.Max(dot(seg.A, n), dot(seg.B, n))+rad
+		rmin, rmax := math.Inf(1), math.Inf(-1)
+		for _, k := range corners {
+			p := dot(k.Float64(), n)
+			rmin, rmax = math.Min(rmin, p), math.Max(rmax, p)
+		}
+		pos, neg := rmax-cmin, cmax-rmin // push c along +n or -n
+		if pos <= 0 || neg <= 0 {
+			return Point[float64]{}, false
+		}
+		if pos < depth {
+			best, depth = n, pos
+		}
+		if neg < depth {
+			best, depth = n.Mul(-1), neg
+		}
+	}
+	return best.Mul(depth), true
+}
+
+func unit(p Point[float64]) Point[float64] {
+	l := math.Hypot(p.X, p.Y)
+	return Xy(p.X/l, p.Y/l)
+}
+
+// awayPoint converts p to S, rounding away from zero for integer S.
+func awayPoint[S ng.Scalar](p Point[float64]) Point[S] {
+	return Point[S]{X: awayS[S](p.X), Y: awayS[S](p.Y)}
+}
+
+// awayS converts f to S, rounding away from zero for integer S.
+func awayS[S ng.Scalar](f float64) S {
+	if isInt[S]() {
+		return S(math.Copysign(math.Ceil(snap(math.Abs(f))), f))
+	}
+	return S(f)
+}
@@ -0,0 +1,124 @@
+package loc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestCircle_Contains(t *testing.T) {
+	c := loc.Circ(loc.Xy(0, 0), 5)
+	if got := c.Bounds(); got != loc.Xyxy(-5, -5, 5, 5) {
+		t.Errorf("Bounds mismatch, got %v", got)
+	}
+	tests := []struct {
+		p    loc.Point[int]
+		want bool
+	}{
+		{loc.Xy(0, 0), true},
+		{loc.Xy(3, 3), true},
+		{loc.Xy(3, 4), false}, // on the circumference
+		{loc.Xy(-5, 0), false},
+		{loc.Xy(4, -2), true},
+	}
+	for _, tt := range tests {
+		if got := c.Contains(tt.p); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestCircle_Overlaps(t *testing.T) {
+	c := loc.Circ(loc.Xy(0, 0), 5)
+	if !c.Overlaps(loc.Circ(loc.Xy(8, 0), 4)) {
+		t.Errorf("overlapping circles not detected")
+	}
+	if c.Overlaps(loc.Circ(loc.Xy(6, 8), 5)) {
+		t.Errorf("touching circles should not overlap")
+	}
+	v, ok := c.Penetration(loc.Circ(loc.Xy(8, 0), 4))
+	if !ok || v != loc.Xy(-1, 0) {
+		t.Errorf("Penetration mismatch, got %v %v", v, ok)
+	}
+	v, ok = c.Penetration(loc.Circ(loc.Xy(0, 0), 2))
+	if !ok || v != loc.Xy(7, 0) {
+		t.Errorf("concentric Penetration mismatch, got %v %v", v, ok)
+	}
+	// Rounded away from zero, the result always separates.
+	o := loc.Circ(loc.Xy(3, 2), 4)
+	v, ok = c.Penetration(o)
+	if !ok || c.Add(v).Overlaps(o) {
+		t.Errorf("integer Penetration %v does not separate", v)
+	}
+}
+
+func TestCircle_OverlapsRect(t *testing.T) {
+	r := loc.Xyxy(0.0, 0.0, 10.0, 10.0)
+	tests := []struct {
+		c    loc.Circle[float64]
+		want bool
+	}{
+		{loc.Circ(loc.Xy(5.0, 5.0), 1), true},
+		{loc.Circ(loc.Xy(12.0, 5.0), 3), true},
+		{loc.Circ(loc.Xy(15.0, 5.0), 5), false}, // touching the right edge
+		{loc.Circ(loc.Xy(13.0, 14.0), 5), false},
+		{loc.Circ(loc.Xy(13.0, 13.0), 5), true},
+	}
+	for _, tt := range tests {
+		if got := tt.c.OverlapsRect(r); got != tt.want {
+			t.Errorf("%v.OverlapsRect = %v, want %v", tt.c, got, tt.want)
+		}
+	}
+
+	v, ok := loc.Circ(loc.Xy(12.0, 5.0), 3).PenetrationRect(r)
+	if !ok || v != loc.Xy(1.0, 0.0) {
+		t.Errorf("PenetrationRect edge mismatch, got %v %v", v, ok)
+	}
+	v, ok = loc.Circ(loc.Xy(13.0, 14.0), 6).PenetrationRect(r)
+	if want := loc.Xy(0.6, 0.8); !ok || math.Abs(v.X-want.X) > 1e-9 || math.Abs(v.Y-want.Y) > 1e-9 {
+		t.Errorf("PenetrationRect corner mismatch, want %v, got %v", want, v)
+	}
+	v, ok = loc.Circ(loc.Xy(8.0, 5.0), 1).PenetrationRect(r)
+	if !ok || v != loc.Xy(3.0, 0.0) {
+		t.Errorf("PenetrationRect inside mismatch, got %v %v", v, ok)
+	}
+	ci := loc.Circ(loc.Xy(12, 13), 5)
+	vi, ok := ci.PenetrationRect(loc.Xyxy(0, 0, 10, 10))
+	if !ok || ci.Add(vi).OverlapsRect(loc.Xyxy(0, 0, 10, 10)) {
+		t.Errorf("integer PenetrationRect %v does not separate", vi)
+	}
+}
+
+func TestCapsule(t *testing.T) {
+	c := loc.Capsule[float64]{A: loc.Xy(0.0, 0.0), B: loc.Xy(10.0, 0.0), Radius: 2}
+	if got := c.Bounds(); got != loc.Xyxy(-2.0, -2.0, 12.0, 2.0) {
+		t.Errorf("Bounds mismatch, got %v", got)
+	}
+	if !c.Contains(loc.Xy(5.0, 1.5)) || c.Contains(loc.Xy(5.0, 2.0)) || !c.Contains(loc.Xy(11.0, 1.0)) {
+		t.Errorf("Contains mismatch")
+	}
+	if !c.OverlapsRect(loc.Xyxy(4.0, 1.0, 6.0, 5.0)) {
+		t.Errorf("OverlapsRect should detect the side")
+	}
+	if c.OverlapsRect(loc.Xyxy(4.0, 2.0, 6.0, 5.0)) {
+		t.Errorf("touching rect should not overlap")
+	}
+	if c.OverlapsRect(loc.Xyxy(11.5, 1.5, 20.0, 20.0)) {
+		t.Errorf("rect off the rounded end should not overlap")
+	}
+	v, ok := c.PenetrationRect(loc.Xyxy(4.0, 1.0, 6.0, 5.0))
+	if !ok || v != loc.Xy(0.0, -1.0) {
+		t.Errorf("PenetrationRect mismatch, got %v %v", v, ok)
+	}
+
+	// A diagonal capsule crossing a rect is pushed along its own normal.
+	d := loc.Capsule[float64]{A: loc.Xy(0.0, 10.0), B: loc.Xy(10.0, 0.0), Radius: 1}
+	v, ok = d.PenetrationRect(loc.Xyxy(0.0, 0.0, 6.0, 6.0))
+	if !ok || math.Abs(math.Hypot(v.X, v.Y)-(1+math.Sqrt(2))) > 1e-9 || v.X <= 0 || v.Y <= 0 {
+		t.Errorf("diagonal PenetrationRect mismatch, got %v", v)
+	}
+	if d.Add(v).OverlapsRect(loc.Xyxy(0.0, 0.0, 6.0, 6.0)) {
+		t.Errorf("PenetrationRect %v does not separate", v)
+	}
+}
@@ -0,0 +1,118 @@
+package loc
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// Damage accumulates invalidated rectangles for incremental redraw.
+//
+// Invalidated rectangles are clipped to Viewport unless it is empty. Two
+// rectangles are merged into their union when the area the union covers in
+// neither of them is at most Waste times the area of the union, so a Waste of
+// 0 only merges rectangles whose union is exact and a Waste of 1 merges
+// everything that overlaps or not. If MaxRects is positive, the pair with the
+// least waste is merged until at most MaxRects rectangles remain.
+// The zero Damage is ready to use.
+type Damage[S ng.Scalar] struct {
+	Viewport Rect[S]
+	Waste    float64
+	MaxRects int
+	rects    []Rect[S]
+}
+
+// Invalidate marks r as needing a redraw.
+func (d *Damage[S]) Invalidate(r Rect[S]) {
+	if !d.Viewport.Empty() {
+		r = r.Intersect(d.Viewport)
+	}
+	if r.Empty() {
+		return
+	}
+	for _, s := range d.rects {
+		if r.In(s) {
+			return
+		}
+	}
+	for {
+		merged := false
+		d.rects = slices.DeleteFunc(d.rects, func(s Rect[S]) bool {
+			if s.In(r) {
+				return true
+			}
+			if waste(r, s) <= d.Waste {
+				r = r.Union(s)
+				merged = true
+				return true
+			}
+			return false
+		})
+		if !merged {
+			break
+		}
+	}
+	d.rects = append(d.rects, r)
+
+	for d.MaxRects > 0 && len(d.rects) > d.MaxRects {
+		bi, bj, best := 0, 1, waste(d.rects[0], d.rects[1])
+		for i := range d.rects {
+			for j := i + 1; j < len(d.rects); j++ {
+				if w := waste(d.rects[i], d.rects[j]); w < best {
+					bi, bj, best = i, j, w
+				}
+			}
+		}
+		d.rects[bi] = d.rects[bi].Union(d.rects[bj])
+		d.rects = slices.Delete(d.rects, bj, bj+1)
+	}
+}
+
+// Empty reports whether nothing has been invalidated.
+func (d *Damage[S]) Empty() bool {
+	return len(d.rects) == 0
+}
+
+// Rects returns the rectangles to repaint. The rectangles may overlap
+// when Waste is positive.
+func (d *Damage[S]) Rects() []Rect[S] {
+	return slices.Clone(d.rects)
+}
+
+// Bounds returns the smallest rectangle containing all damage.
+func (d *Damage[S]) Bounds() Rect[S] {
+	var b Rect[S]
+	for _, r := range d.rects {
+		b = b.Union(r)
+	}
+	return b
+}
+
+// Flush returns the rectangles to repaint and clears the damage for the next frame.
+func (d *Damage[S]) Flush() []Rect[S] {
+	rects := d.rects
+	d.rects = nil
+	return rects
+}
+
+// Reset clears the damage.
+func (d *Damage[S]) Reset() {
+	d.rects = d.rects[:0]
+}
+
+// area returns the area of r as a float64, which cannot overflow.
+func area[S ng.Scalar](r Rect[S]) float64 {
+	if r.Empty() {
+		return 0
+	}
+	return float64(r.Dx()) * float64(r.Dy())
+}
+
+// waste returns the fraction of the union of r and s covered by neither.
+func waste[S ng.Scalar](r, s Rect[S]) float64 {
+	u := area(r.Union(s))
+	if u == 0 {
+		return 0
+	}
+	return (u - area(r) - area(s) + area(r.Intersect(s))) / u
+}
@@ -0,0 +1,55 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestDamage_Merge(t *testing.T) {
+	d := loc.Damage[int]{Waste: 0.25}
+	d.Invalidate(loc.Xyxy(0, 0, 10, 10))
+	d.Invalidate(loc.Xyxy(10, 0, 20, 10)) // exact union
+	d.Invalidate(loc.Xyxy(100, 100, 110, 110))
+	d.Invalidate(loc.Xyxy(2, 2, 5, 5)) // already covered
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 20, 10),
+		loc.Xyxy(100, 100, 110, 110),
+	}
+	if got := d.Flush(); !slices.Equal(want, got) {
+		t.Errorf("Flush mismatch, want %v, got %v", want, got)
+	}
+	if !d.Empty() {
+		t.Errorf("Flush should clear the damage")
+	}
+}
+
+func TestDamage_Chain(t *testing.T) {
+	d := loc.Damage[int]{}
+	d.Invalidate(loc.Xyxy(0, 0, 10, 10))
+	d.Invalidate(loc.Xyxy(20, 0, 30, 10))
+	d.Invalidate(loc.Xyxy(10, 0, 20, 10)) // bridges both
+	want := []loc.Rect[int]{loc.Xyxy(0, 0, 30, 10)}
+	if got := d.Rects(); !slices.Equal(want, got) {
+		t.Errorf("Rects mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestDamage_ViewportAndCap(t *testing.T) {
+	d := loc.Damage[int]{Viewport: loc.Xyxy(0, 0, 100, 100), MaxRects: 2}
+	d.Invalidate(loc.Xyxy(-10, -10, 10, 10))
+	d.Invalidate(loc.Xyxy(200, 200, 210, 210)) // outside
+	d.Invalidate(loc.Xyxy(90, 0, 100, 10))
+	d.Invalidate(loc.Xyxy(0, 90, 10, 100))
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 100, 10),
+		loc.Xyxy(0, 90, 10, 100),
+	}
+	if got := d.Rects(); !slices.Equal(want, got) {
+		t.Errorf("Rects mismatch, want %v, got %v", want, got)
+	}
+	if want := loc.Xyxy(0, 0, 100, 100); d.Bounds() != want {
+		t.Errorf("Bounds mismatch, want %v, got %v", want, d.Bounds())
+	}
+}
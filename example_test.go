@@ -207,3 +207,22 @@ func ExampleRect_RepeatY() {
 	// Part 0: (0,0)-(20,30)
 	// Part 1: (0,40)-(20,70)
 }
+
+func ExampleRect_TracksX() {
+	toolbar := loc.Xyxy(0, 0, 300, 40)
+	cells := toolbar.TracksX(10,
+		loc.Fixed(40),               // icon
+		loc.Fr[int](2),              // title
+		loc.Fr[int](1).Clamp(0, 50), // search, at most 50
+		loc.Percent[int](20),        // status
+	)
+	for i, c := range cells {
+		fmt.Printf("Cell %d: %s\n", i, c)
+	}
+
+	// Output:
+	// Cell 0: (0,0)-(40,40)
+	// Cell 1: (50,0)-(176,40)
+	// Cell 2: (186,0)-(236,40)
+	// Cell 3: (246,0)-(300,40)
+}
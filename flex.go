@@ -0,0 +1,218 @@
+package loc
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// A FlexDirection is the main axis of a Flex layout.
+type FlexDirection int
+
+const (
+	FlexRow    FlexDirection = iota // items flow along X
+	FlexColumn                      // items flow along Y
+)
+
+// A Justify determines how free space on the main axis is distributed.
+type Justify int
+
+const (
+	JustifyStart Justify = iota
+	JustifyCenter
+	JustifyEnd
+	JustifySpaceBetween
+	JustifySpaceAround
+	JustifySpaceEvenly
+)
+
+// A CrossAlign determines how an item is placed on the cross axis of its line.
+type CrossAlign int
+
+const (
+	CrossAuto CrossAlign = iota // use the container's alignment
+	CrossStart
+	CrossCenter
+	CrossEnd
+	CrossStretch
+)
+
+// rate returns the relative position used with Point.Align.
+func (a CrossAlign) rate() float64 {
+	switch a {
+	case CrossCenter:
+		return 0.5
+	case CrossEnd:
+		return 1
+	}
+	return 0
+}
+
+// A FlexItem is an item laid out by Flex.
+// Basis, Min and Max are sizes on the main axis, and a zero Max means no
+// upper bound. Cross is the size on the cross axis, ignored when the item is
+// stretched.
+type FlexItem[S ng.Scalar] struct {
+	Basis        S
+	Grow, Shrink float64
+	Min, Max     S
+	Cross        S
+	Align        CrossAlign
+}
+
+func (it FlexItem[S]) clampFloat(f float64) float64 {
+	if it.Max > 0 && f > float64(it.Max) {
+		f = float64(it.Max)
+	}
+	if f < float64(it.Min) {
+		f = float64(it.Min)
+	}
+	return f
+}
+
+// Flex is a flexbox-style layout. Gap is the space between items on the main
+// axis and LineGap the space between lines when Wrap is set.
+type Flex[S ng.Scalar] struct {
+	Direction FlexDirection
+	Justify   Justify
+	Align     CrossAlign
+	Wrap      bool
+	Gap       S
+	LineGap   S
+}
+
+// Layout places items in r and returns one rectangle per item.
+// Items that do not fit overflow r.
+func (f Flex[S]) Layout(r Rect[S], items []FlexItem[S]) []Rect[S] {
+	if len(items) == 0 {
+		return nil
+	}
+	mainLen, crossLen := r.Dx(), r.Dy()
+	if f.Direction == FlexColumn {
+		mainLen, crossLen = crossLen, mainLen
+	}
+
+	// Break items into lines.
+	var lines [][]int
+	var line []int
+	var used S
+	for i, it := range items {
+		size := S(it.clampFloat(float64(it.Basis)))
+		if f.Wrap && len(line) > 0 && used+f.Gap+size > mainLen {
+			lines = append(lines, line)
+			line, used = nil, 0
+		}
+		if len(line) > 0 {
+			used += f.Gap
+		}
+		line = append(line, i)
+		used += size
+	}
+	lines = append(lines, line)
+
+	// Size the lines on the cross axis, sharing any extra space equally.
+	crosses := make([]float64, len(lines))
+	var crossUsed float64
+	for l, line := range lines {
+		for _, i := range line {
+			crosses[l] = max(crosses[l], float64(items[i].Cross))
+		}
+		crossUsed += crosses[l]
+	}
+	crossUsed += float64(f.LineGap) * float64(len(lines)-1)
+	if extra := float64(crossLen) - crossUsed; extra > 0 {
+		for l := range crosses {
+			crosses[l] += extra / float64(len(lines))
+		}
+	}
+	lineSizes := apportion[S](crosses)
+
+	rects := make([]Rect[S], len(items))
+	crossPos := float64(0)
+	for l, line := range lines {
+		f.layoutLine(rects, items, line, mainLen, lineSizes[l], r, floorS[S](crossPos))
+		crossPos += float64(lineSizes[l]) + float64(f.LineGap)
+	}
+	return rects
+}
+
+// layoutLine sizes and places the items of one line, writing into rects.
+func (f Flex[S]) layoutLine(rects []Rect[S], items []FlexItem[S], line []int, mainLen, crossLen S, r Rect[S], crossPos S) {
+	n := len(line)
+	avail := float64(mainLen) - float64(f.Gap)*float64(n-1)
+	base := make([]float64, n)
+	weights := make([]float64, n)
+	free, hypo := avail, avail
+	for j, i := range line {
+		base[j] = float64(items[i].Basis)
+		free -= base[j]
+		hypo -= items[i].clampFloat(base[j])
+	}
+	for j, i := range line {
+		if hypo >= 0 {
+			weights[j] = items[i].Grow
+		} else {
+			// Shrinking is weighted by the basis so large items shrink more.
+			weights[j] = items[i].Shrink * base[j]
+		}
+	}
+	sizes := apportion[S](distribute(base, free, weights, func(j int, s float64) float64 {
+		return items[line[j]].clampFloat(s)
+	}))
+
+	left := avail
+	for _, s := range sizes {
+		left -= float64(s)
+	}
+	left = max(left, 0)
+	var start, between float64
+	switch f.Justify {
+	case JustifyCenter:
+		start = left / 2
+	case JustifyEnd:
+		start = left
+	case JustifySpaceBetween:
+		if n > 1 {
+			between = left / float64(n-1)
+		}
+	case JustifySpaceAround:
+		between = left / float64(n)
+		start = between / 2
+	case JustifySpaceEvenly:
+		between = left / float64(n+1)
+		start = between
+	}
+
+	pos := start
+	for j, i := range line {
+		it := items[i]
+		align := it.Align
+		if align == CrossAuto {
+			align = f.Align
+		}
+		cross := it.Cross
+		if align == CrossStretch {
+			cross = crossLen
+		}
+		m := floorS[S](pos)
+		var cell, size Rect[S]
+		if f.Direction == FlexColumn {
+			cell = Xywh(r.Min.X+crossPos, r.Min.Y+m, crossLen, sizes[j])
+			size = Xywh(0, 0, cross, sizes[j])
+			rects[i] = size.Within(cell, align.rate(), 0)
+		} else {
+			cell = Xywh(r.Min.X+m, r.Min.Y+crossPos, sizes[j], crossLen)
+			size = Xywh(0, 0, sizes[j], cross)
+			rects[i] = size.Within(cell, 0, align.rate())
+		}
+		pos += float64(sizes[j]) + float64(f.Gap) + between
+	}
+}
+
+// floorS converts f to S, rounding down for integer S.
+func floorS[S ng.Scalar](f float64) S {
+	if isInt[S]() {
+		return S(math.Floor(f))
+	}
+	return S(f)
+}
@@ -0,0 +1,99 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestFlex_Grow(t *testing.T) {
+	f := loc.Flex[int]{Gap: 10, Align: loc.CrossStretch}
+	got := f.Layout(loc.Xyxy(0, 0, 200, 40), []loc.FlexItem[int]{
+		{Basis: 40},
+		{Basis: 0, Grow: 1},
+		{Basis: 20, Grow: 1, Max: 50},
+	})
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 40, 40),
+		loc.Xyxy(50, 0, 140, 40),
+		loc.Xyxy(150, 0, 200, 40),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout grow mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestFlex_Shrink(t *testing.T) {
+	f := loc.Flex[int]{}
+	got := f.Layout(loc.Xyxy(0, 0, 90, 20), []loc.FlexItem[int]{
+		{Basis: 60, Shrink: 1, Cross: 20},
+		{Basis: 60, Shrink: 1, Min: 50, Cross: 20},
+	})
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 40, 20),
+		loc.Xyxy(40, 0, 90, 20),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout shrink mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestFlex_Justify(t *testing.T) {
+	items := []loc.FlexItem[int]{{Basis: 10, Cross: 10}, {Basis: 10, Cross: 10}}
+	r := loc.Xyxy(0, 0, 100, 10)
+	tests := []struct {
+		justify loc.Justify
+		want    []int
+	}{
+		{loc.JustifyStart, []int{0, 10}},
+		{loc.JustifyCenter, []int{40, 50}},
+		{loc.JustifyEnd, []int{80, 90}},
+		{loc.JustifySpaceBetween, []int{0, 90}},
+		{loc.JustifySpaceAround, []int{20, 70}},
+		{loc.JustifySpaceEvenly, []int{26, 63}},
+	}
+	for _, tt := range tests {
+		got := loc.Flex[int]{Justify: tt.justify}.Layout(r, items)
+		for i, x := range tt.want {
+			if got[i].Min.X != x {
+				t.Errorf("Justify %d item %d mismatch, want x=%d, got %v", tt.justify, i, x, got[i])
+			}
+		}
+	}
+}
+
+func TestFlex_CrossAlign(t *testing.T) {
+	f := loc.Flex[int]{Direction: loc.FlexColumn, Align: loc.CrossCenter}
+	got := f.Layout(loc.Xyxy(0, 0, 100, 100), []loc.FlexItem[int]{
+		{Basis: 20, Cross: 40},
+		{Basis: 20, Cross: 40, Align: loc.CrossEnd},
+		{Basis: 20, Align: loc.CrossStretch},
+	})
+	want := []loc.Rect[int]{
+		loc.Xyxy(30, 0, 70, 20),
+		loc.Xyxy(60, 20, 100, 40),
+		loc.Xyxy(0, 40, 100, 60),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout cross align mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestFlex_Wrap(t *testing.T) {
+	f := loc.Flex[int]{Wrap: true, Gap: 10, LineGap: 10}
+	got := f.Layout(loc.Xyxy(0, 0, 100, 100), []loc.FlexItem[int]{
+		{Basis: 40, Cross: 20},
+		{Basis: 40, Cross: 30},
+		{Basis: 40, Cross: 20},
+	})
+	// Two lines of 30 and 20 share the 40 extra cross space equally.
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 40, 20),
+		loc.Xyxy(50, 0, 90, 30),
+		loc.Xyxy(0, 60, 40, 80),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout wrap mismatch, want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,155 @@
+package loc
+
+import (
+	"strings"
+
+	"github.com/eihigh/ng"
+)
+
+// A GridItem is an item placed in a Grid. It occupies RowSpan rows starting at
+// Row and ColSpan columns starting at Col; spans less than 1 count as 1. If
+// Area is set, the item is placed on the named area instead. Size is the
+// content size used by auto tracks.
+type GridItem[S ng.Scalar] struct {
+	Row, Col         int
+	RowSpan, ColSpan int
+	Area             string
+	Size             Point[S]
+}
+
+// Grid is a two-dimensional layout of row and column tracks.
+//
+// Areas optionally names regions of the grid, one string per row with one
+// whitespace-separated name per column, like CSS grid-template-areas.
+// A "." marks an unnamed cell. An area covers the bounding box of the cells
+// carrying its name.
+type Grid[S ng.Scalar] struct {
+	Rows, Cols     []Track[S]
+	RowGap, ColGap S
+	Areas          []string
+}
+
+// Area returns the placement of the named area and reports whether it exists.
+func (g Grid[S]) Area(name string) (row, col, rowSpan, colSpan int, ok bool) {
+	r0, c0, r1, c1 := 0, 0, -1, -1
+	for r, line := range g.Areas {
+		for c, cell := range strings.Fields(line) {
+			if cell != name || cell == "." {
+				continue
+			}
+			if !ok {
+				r0, c0, r1, c1 = r, c, r, c
+				ok = true
+				continue
+			}
+			r0, c0 = min(r0, r), min(c0, c)
+			r1, c1 = max(r1, r), max(c1, c)
+		}
+	}
+	return r0, c0, r1 - r0 + 1, c1 - c0 + 1, ok
+}
+
+// Layout places items in r. It returns one rectangle per item and the bounds
+// of the whole grid, which exceed r when the tracks do not fit.
+// Items outside the grid are moved to its last row or column.
+func (g Grid[S]) Layout(r Rect[S], items []GridItem[S]) ([]Rect[S], Rect[S]) {
+	spans := make([]GridItem[S], len(items))
+	for i, it := range items {
+		spans[i] = g.place(it)
+	}
+
+	rows := g.autoTracks(g.Rows, spans, func(it GridItem[S]) (int, int, S) {
+		return it.Row, it.RowSpan, it.Size.Y
+	})
+	cols := g.autoTracks(g.Cols, spans, func(it GridItem[S]) (int, int, S) {
+		return it.Col, it.ColSpan, it.Size.X
+	})
+	hs := TrackSizes(available(r.Dy(), len(rows), g.RowGap), rows)
+	ws := TrackSizes(available(r.Dx(), len(cols), g.ColGap), cols)
+	if len(hs) == 0 || len(ws) == 0 {
+		return make([]Rect[S], len(items)), Rect[S]{Min: r.Min, Max: r.Min}
+	}
+	ys := trackStarts(r.Min.Y, g.RowGap, hs)
+	xs := trackStarts(r.Min.X, g.ColGap, ws)
+	last := func(starts, sizes []S) S {
+		return starts[len(starts)-1] + sizes[len(sizes)-1]
+	}
+	bounds := Xyxy(r.Min.X, r.Min.Y, last(xs, ws), last(ys, hs))
+
+	rects := make([]Rect[S], len(items))
+	for i, it := range spans {
+		r1, c1 := it.Row+it.RowSpan-1, it.Col+it.ColSpan-1
+		rects[i] = Xyxy(xs[it.Col], ys[it.Row], xs[c1]+ws[c1], ys[r1]+hs[r1])
+	}
+	return rects, bounds
+}
+
+// LayoutAreas returns the rectangle of every named area of g laid out in r.
+func (g Grid[S]) LayoutAreas(r Rect[S]) map[string]Rect[S] {
+	var names []string
+	seen := map[string]bool{}
+	for _, line := range g.Areas {
+		for _, cell := range strings.Fields(line) {
+			if cell != "." && !seen[cell] {
+				seen[cell] = true
+				names = append(names, cell)
+			}
+		}
+	}
+	items := make([]GridItem[S], len(names))
+	for i, name := range names {
+		items[i].Area = name
+	}
+	rects, _ := g.Layout(r, items)
+	areas := make(map[string]Rect[S], len(names))
+	for i, name := range names {
+		areas[name] = rects[i]
+	}
+	return areas
+}
+
+// place resolves the area and clamps the item into the grid.
+func (g Grid[S]) place(it GridItem[S]) GridItem[S] {
+	if it.Area != "" {
+		if row, col, rs, cs, ok := g.Area(it.Area); ok {
+			it.Row, it.Col, it.RowSpan, it.ColSpan = row, col, rs, cs
+		}
+	}
+	clamp := func(start, span, n int) (int, int) {
+		start = min(max(start, 0), max(n-1, 0))
+		span = min(max(span, 1), max(n-start, 1))
+		return start, span
+	}
+	it.Row, it.RowSpan = clamp(it.Row, it.RowSpan, len(g.Rows))
+	it.Col, it.ColSpan = clamp(it.Col, it.ColSpan, len(g.Cols))
+	return it
+}
+
+// autoTracks returns a copy of tracks with the size of each auto track set to
+// the largest item spanning only that track.
+func (g Grid[S]) autoTracks(tracks []Track[S], items []GridItem[S], axis func(GridItem[S]) (start, span int, size S)) []Track[S] {
+	tracks = append([]Track[S](nil), tracks...)
+	for i := range tracks {
+		if tracks[i].Kind == TrackAuto {
+			tracks[i].Size = 0
+		}
+	}
+	for _, it := range items {
+		start, span, size := axis(it)
+		if span == 1 && start < len(tracks) && tracks[start].Kind == TrackAuto {
+			tracks[start].Size = max(tracks[start].Size, size)
+		}
+	}
+	return tracks
+}
+
+// trackStarts returns the start position of each track.
+func trackStarts[S ng.Scalar](origin, gap S, sizes []S) []S {
+	starts := make([]S, len(sizes))
+	pos := origin
+	for i, s := range sizes {
+		starts[i] = pos
+		pos += s + gap
+	}
+	return starts
+}
@@ -0,0 +1,81 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestGrid_Spans(t *testing.T) {
+	g := loc.Grid[int]{
+		Rows:   []loc.Track[int]{loc.Fixed(20), loc.Fixed(20)},
+		Cols:   []loc.Track[int]{loc.Auto[int](), loc.Fr[int](1), loc.Fr[int](1)},
+		RowGap: 5,
+		ColGap: 10,
+	}
+	got, bounds := g.Layout(loc.Xyxy(0, 0, 200, 100), []loc.GridItem[int]{
+		{Row: 0, Col: 0, Size: loc.Xy(50, 10)},
+		{Row: 0, Col: 1, ColSpan: 2},
+		{Row: 1, Col: 0, Size: loc.Xy(30, 10)},
+		{Row: 1, Col: 1},
+		{Row: 1, Col: 2},
+	})
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 50, 20),
+		loc.Xyxy(60, 0, 200, 20),
+		loc.Xyxy(0, 25, 50, 45),
+		loc.Xyxy(60, 25, 125, 45),
+		loc.Xyxy(135, 25, 200, 45),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout mismatch, want %v, got %v", want, got)
+	}
+	if wantBounds := loc.Xyxy(0, 0, 200, 45); bounds != wantBounds {
+		t.Errorf("Layout bounds mismatch, want %v, got %v", wantBounds, bounds)
+	}
+}
+
+func TestGrid_OutOfRange(t *testing.T) {
+	g := loc.Grid[int]{
+		Rows: []loc.Track[int]{loc.Fr[int](1)},
+		Cols: []loc.Track[int]{loc.Fr[int](1), loc.Fr[int](1)},
+	}
+	got, _ := g.Layout(loc.Xyxy(0, 0, 100, 100), []loc.GridItem[int]{
+		{Row: 3, Col: 1, ColSpan: 5},
+	})
+	want := []loc.Rect[int]{loc.Xyxy(50, 0, 100, 100)}
+	if !slices.Equal(want, got) {
+		t.Errorf("Layout out of range mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestGrid_Areas(t *testing.T) {
+	g := loc.Grid[int]{
+		Rows: []loc.Track[int]{loc.Fixed(10), loc.Fr[int](1), loc.Fixed(10)},
+		Cols: []loc.Track[int]{loc.Fixed(30), loc.Fr[int](1)},
+		Areas: []string{
+			"header header",
+			"nav    main",
+			".      footer",
+		},
+	}
+	got := g.LayoutAreas(loc.Xyxy(0, 0, 100, 100))
+	want := map[string]loc.Rect[int]{
+		"header": loc.Xyxy(0, 0, 100, 10),
+		"nav":    loc.Xyxy(0, 10, 30, 90),
+		"main":   loc.Xyxy(30, 10, 100, 90),
+		"footer": loc.Xyxy(30, 90, 100, 100),
+	}
+	if len(want) != len(got) {
+		t.Errorf("LayoutAreas length mismatch, want %d, got %d", len(want), len(got))
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("LayoutAreas %q mismatch, want %v, got %v", name, w, got[name])
+		}
+	}
+	if _, _, _, _, ok := g.Area("sidebar"); ok {
+		t.Errorf("Area(sidebar) should not exist")
+	}
+}
@@ -0,0 +1,112 @@
+package loc
+
+import (
+	"fmt"
+
+	"github.com/eihigh/ng"
+)
+
+// Insets are distances inward from each edge of a rectangle, such as a
+// device safe area or space taken by an on-screen keyboard.
+type Insets[S ng.Scalar] struct {
+	Left, Top, Right, Bottom S
+}
+
+// Ltrb is shorthand for Insets{left, top, right, bottom}.
+func Ltrb[S ng.Scalar](left, top, right, bottom S) Insets[S] {
+	return Insets[S]{Left: left, Top: top, Right: right, Bottom: bottom}
+}
+
+// UniformInsets returns insets of n on every edge.
+func UniformInsets[S ng.Scalar](n S) Insets[S] {
+	return Insets[S]{Left: n, Top: n, Right: n, Bottom: n}
+}
+
+// String returns a string representation of in like "[1 2 3 4]".
+func (in Insets[S]) String() string {
+	return fmt.Sprint([]S{in.Left, in.Top, in.Right, in.Bottom})
+}
+
+// Add returns the insets in+o, for stacking insets such as a docked panel
+// inside the safe area.
+func (in Insets[S]) Add(o Insets[S]) Insets[S] {
+	return Insets[S]{
+		Left:   in.Left + o.Left,
+		Top:    in.Top + o.Top,
+		Right:  in.Right + o.Right,
+		Bottom: in.Bottom + o.Bottom,
+	}
+}
+
+// Max returns the larger of in and o on each edge, for insets from sources
+// that overlap such as a notch and a status bar.
+func (in Insets[S]) Max(o Insets[S]) Insets[S] {
+	return Insets[S]{
+		Left:   max(in.Left, o.Left),
+		Top:    max(in.Top, o.Top),
+		Right:  max(in.Right, o.Right),
+		Bottom: max(in.Bottom, o.Bottom),
+	}
+}
+
+// Min returns the smaller of in and o on each edge.
+func (in Insets[S]) Min(o Insets[S]) Insets[S] {
+	return Insets[S]{
+		Left:   min(in.Left, o.Left),
+		Top:    min(in.Top, o.Top),
+		Right:  min(in.Right, o.Right),
+		Bottom: min(in.Bottom, o.Bottom),
+	}
+}
+
+// Scale returns in scaled by k, such as a device pixel ratio.
+func (in Insets[S]) Scale(k float64) Insets[S] {
+	return Insets[S]{
+		Left:   rel(in.Left, k),
+		Top:    rel(in.Top, k),
+		Right:  rel(in.Right, k),
+		Bottom: rel(in.Bottom, k),
+	}
+}
+
+// Flip returns in with Left and Right swapped, for right-to-left layouts.
+func (in Insets[S]) Flip() Insets[S] {
+	in.Left, in.Right = in.Right, in.Left
+	return in
+}
+
+// Dx returns the total horizontal inset, Left+Right.
+func (in Insets[S]) Dx() S {
+	return in.Left + in.Right
+}
+
+// Dy returns the total vertical inset, Top+Bottom.
+func (in Insets[S]) Dy() S {
+	return in.Top + in.Bottom
+}
+
+// InsetBy returns the rectangle r inset by in. As with Inset4, if either of
+// r's dimensions is less than the insets then an empty rectangle near the
+// center of r will be returned.
+func (r Rect[S]) InsetBy(in Insets[S]) Rect[S] {
+	return r.Inset4(in.Left, in.Top, in.Right, in.Bottom)
+}
+
+// OutsetBy returns the rectangle r grown outward by in.
+func (r Rect[S]) OutsetBy(in Insets[S]) Rect[S] {
+	return Rect[S]{
+		Min: Point[S]{X: r.Min.X - in.Left, Y: r.Min.Y - in.Top},
+		Max: Point[S]{X: r.Max.X + in.Right, Y: r.Max.Y + in.Bottom},
+	}
+}
+
+// InsetsTo returns the insets that turn r into inner, which is normally
+// nested in r. Edges of inner outside r give negative insets.
+func (r Rect[S]) InsetsTo(inner Rect[S]) Insets[S] {
+	return Insets[S]{
+		Left:   inner.Min.X - r.Min.X,
+		Top:    inner.Min.Y - r.Min.Y,
+		Right:  r.Max.X - inner.Max.X,
+		Bottom: r.Max.Y - inner.Max.Y,
+	}
+}
@@ -0,0 +1,52 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestInsets_Compose(t *testing.T) {
+	safe := loc.Ltrb(0, 44, 0, 34)
+	keyboard := loc.Ltrb(0, 0, 0, 300)
+	panel := loc.Ltrb(200, 0, 0, 0)
+	got := safe.Max(keyboard).Add(panel)
+	if want := loc.Ltrb(200, 44, 0, 300); got != want {
+		t.Errorf("compose mismatch, want %v, got %v", want, got)
+	}
+	if want := loc.Ltrb(0, 44, 200, 300); got.Flip() != want {
+		t.Errorf("Flip mismatch, want %v, got %v", want, got.Flip())
+	}
+	if want := loc.Ltrb(0, 0, 0, 34); safe.Min(keyboard) != want {
+		t.Errorf("Min mismatch, want %v, got %v", want, safe.Min(keyboard))
+	}
+	if want := loc.Ltrb(0, 88, 0, 68); safe.Scale(2) != want {
+		t.Errorf("Scale mismatch, want %v, got %v", want, safe.Scale(2))
+	}
+}
+
+func TestRect_InsetBy(t *testing.T) {
+	screen := loc.Xyxy(0, 0, 400, 800)
+	in := loc.Ltrb(10, 44, 20, 34)
+	got := screen.InsetBy(in)
+	if want := loc.Xyxy(10, 44, 380, 766); got != want {
+		t.Errorf("InsetBy mismatch, want %v, got %v", want, got)
+	}
+	if back := got.OutsetBy(in); back != screen {
+		t.Errorf("OutsetBy mismatch, want %v, got %v", screen, back)
+	}
+	if between := screen.InsetsTo(got); between != in {
+		t.Errorf("InsetsTo mismatch, want %v, got %v", in, between)
+	}
+	if in.Dx() != 30 || in.Dy() != 78 {
+		t.Errorf("Dx, Dy mismatch, got %d, %d", in.Dx(), in.Dy())
+	}
+}
+
+func TestRect_InsetBy_TooLarge(t *testing.T) {
+	got := loc.Xyxy(0, 0, 100, 50).InsetBy(loc.UniformInsets(60))
+	want := loc.Xyxy(50, 25, 50, 25)
+	if got != want || !got.Empty() {
+		t.Errorf("InsetBy too large mismatch, want %v, got %v", want, got)
+	}
+}
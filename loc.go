@@ -8,7 +8,8 @@ import (
 	"github.com/eihigh/ng"
 )
 
-// A Point is an X, Y coordinate pair. The axes increase right and down.
+// A Point is an X, Y coordinate pair. The axes increase right and down;
+// see FlipY and YUpRect for Y-up coordinates.
 type Point[S ng.Scalar] struct {
 	X, Y S
 }
@@ -73,6 +74,16 @@ func (p Point[S]) Image() image.Point {
 	return image.Point{X: int(p.X), Y: int(p.Y)}
 }
 
+// Vec2 returns the point as an ng.Vec2.
+func (p Point[S]) Vec2() ng.Vec2[S] {
+	return ng.Vec2[S]{X: p.X, Y: p.Y}
+}
+
+// AsPoint converts any X, Y struct such as ng.Vec2 or image.Point to a point.
+func AsPoint[S ng.Scalar, P ng.Vec2like[S]](v P) Point[S] {
+	return Point[S](v)
+}
+
 // Int returns the point as an int point.
 func (p Point[S]) Int() Point[int] {
 	return Point[int]{X: int(p.X), Y: int(p.Y)}
@@ -0,0 +1,200 @@
+package loc
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A WritingMode maps the logical directions of a layout onto X and Y.
+// The inline direction is the direction text runs in, and the block
+// direction is the direction lines stack in.
+type WritingMode int
+
+const (
+	HorizontalLTR WritingMode = iota // inline +X, block +Y
+	HorizontalRTL                    // inline -X, block +Y
+	VerticalRL                       // inline +Y, block -X
+	VerticalLR                       // inline +Y, block +X
+)
+
+// Vertical reports whether the inline direction of m is along Y.
+func (m WritingMode) Vertical() bool {
+	return m == VerticalRL || m == VerticalLR
+}
+
+// Physical converts the relative inline and block positions ri and rb, where
+// 0 is the start edge, to the relative positions rx and ry used by
+// Rect.Anchor and Point.Align.
+func (m WritingMode) Physical(ri, rb float64) (rx, ry float64) {
+	switch m {
+	case HorizontalRTL:
+		return 1 - ri, rb
+	case VerticalRL:
+		return 1 - rb, ri
+	case VerticalLR:
+		return rb, ri
+	}
+	return ri, rb
+}
+
+// reversed reports whether the inline (or block) direction runs toward
+// decreasing coordinates.
+func (m WritingMode) reversed(inline bool) bool {
+	if inline {
+		return m == HorizontalRTL
+	}
+	return m == VerticalRL
+}
+
+// A LogicalRect is a rectangle viewed in the logical directions of a
+// writing mode, so one layout description works for every locale.
+type LogicalRect[S ng.Scalar] struct {
+	Rect Rect[S]
+	Mode WritingMode
+}
+
+// Logical returns r viewed in the logical directions of mode.
+func (r Rect[S]) Logical(mode WritingMode) LogicalRect[S] {
+	return LogicalRect[S]{Rect: r, Mode: mode}
+}
+
+// InlineSize returns the size of l along the inline direction.
+func (l LogicalRect[S]) InlineSize() S {
+	if l.Mode.Vertical() {
+		return l.Rect.Dy()
+	}
+	return l.Rect.Dx()
+}
+
+// BlockSize returns the size of l along the block direction.
+func (l LogicalRect[S]) BlockSize() S {
+	if l.Mode.Vertical() {
+		return l.Rect.Dx()
+	}
+	return l.Rect.Dy()
+}
+
+// Anchor returns the point at the relative inline and block positions
+// (ri, rb), where 0, 0 is the inline-start, block-start corner.
+func (l LogicalRect[S]) Anchor(ri, rb float64) Point[S] {
+	return l.Rect.Anchor(l.Mode.Physical(ri, rb))
+}
+
+// Within returns a rectangle with the size of l placed within s at the
+// relative inline and block positions (ri, rb).
+func (l LogicalRect[S]) Within(s Rect[S], ri, rb float64) Rect[S] {
+	rx, ry := l.Mode.Physical(ri, rb)
+	return l.Rect.Within(s, rx, ry)
+}
+
+// Align returns a rectangle with the size of l where p is at the relative
+// inline and block positions (ri, rb), like Point.Align.
+func (l LogicalRect[S]) Align(p Point[S], ri, rb float64) Rect[S] {
+	rx, ry := l.Mode.Physical(ri, rb)
+	return p.Align(l.Rect, rx, ry)
+}
+
+// CutInlineStart cuts n from the inline-start edge of l. It returns the cut
+// part (got) and the rest, clamped as with CutX.
+func (l LogicalRect[S]) CutInlineStart(n S) (got, rest Rect[S]) {
+	return l.cut(true, false, n)
+}
+
+// CutInlineEnd cuts n from the inline-end edge of l.
+func (l LogicalRect[S]) CutInlineEnd(n S) (got, rest Rect[S]) {
+	return l.cut(true, true, n)
+}
+
+// CutBlockStart cuts n from the block-start edge of l.
+func (l LogicalRect[S]) CutBlockStart(n S) (got, rest Rect[S]) {
+	return l.cut(false, false, n)
+}
+
+// CutBlockEnd cuts n from the block-end edge of l.
+func (l LogicalRect[S]) CutBlockEnd(n S) (got, rest Rect[S]) {
+	return l.cut(false, true, n)
+}
+
+// cut cuts n from the start or end of the inline or block axis.
+func (l LogicalRect[S]) cut(inline, end bool, n S) (got, rest Rect[S]) {
+	r := l.Rect
+	alongY := l.Mode.Vertical() == inline
+	fromMax := l.Mode.reversed(inline) != end
+	switch {
+	case !alongY && !fromMax:
+		return r.CutX(n)
+	case alongY && !fromMax:
+		return r.CutY(n)
+	case !alongY:
+		n = min(max(n, 0), r.Dx())
+		rest, got = r.CutX(r.Dx() - n)
+	default:
+		n = min(max(n, 0), r.Dy())
+		rest, got = r.CutY(r.Dy() - n)
+	}
+	return got, rest
+}
+
+// SplitInline splits l into n parts along the inline direction, like SplitX,
+// ordered from the inline start.
+func (l LogicalRect[S]) SplitInline(n int, gap S) []Rect[S] {
+	return l.split(true, n, gap)
+}
+
+// SplitBlock splits l into n parts along the block direction, ordered from
+// the block start.
+func (l LogicalRect[S]) SplitBlock(n int, gap S) []Rect[S] {
+	return l.split(false, n, gap)
+}
+
+// TracksInline splits l into tracks along the inline direction, like
+// TracksX, with the first track at the inline start.
+func (l LogicalRect[S]) TracksInline(gap S, tracks ...Track[S]) []Rect[S] {
+	return l.tracks(true, gap, tracks)
+}
+
+// TracksBlock splits l into tracks along the block direction, with the first
+// track at the block start.
+func (l LogicalRect[S]) TracksBlock(gap S, tracks ...Track[S]) []Rect[S] {
+	return l.tracks(false, gap, tracks)
+}
+
+func (l LogicalRect[S]) split(inline bool, n int, gap S) []Rect[S] {
+	var rects []Rect[S]
+	if l.Mode.Vertical() == inline {
+		rects = l.Rect.SplitY(n, gap)
+	} else {
+		rects = l.Rect.SplitX(n, gap)
+	}
+	if l.Mode.reversed(inline) {
+		slices.Reverse(rects)
+	}
+	return rects
+}
+
+// tracks lays the tracks out from the start edge of the axis. Reversed axes
+// are laid out forward and then mirrored about the rectangle, so that tracks
+// which overflow it spill past the end edge rather than the start edge.
+func (l LogicalRect[S]) tracks(inline bool, gap S, tracks []Track[S]) []Rect[S] {
+	r := l.Rect
+	vertical := l.Mode.Vertical() == inline
+	var rects []Rect[S]
+	if vertical {
+		rects = r.TracksY(gap, tracks...)
+	} else {
+		rects = r.TracksX(gap, tracks...)
+	}
+	if !l.Mode.reversed(inline) {
+		return rects
+	}
+	for i, t := range rects {
+		if vertical {
+			t.Min.Y, t.Max.Y = r.Min.Y+r.Max.Y-t.Max.Y, r.Min.Y+r.Max.Y-t.Min.Y
+		} else {
+			t.Min.X, t.Max.X = r.Min.X+r.Max.X-t.Max.X, r.Min.X+r.Max.X-t.Min.X
+		}
+		rects[i] = t
+	}
+	return rects
+}
@@ -0,0 +1,106 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestLogicalRect_Cut(t *testing.T) {
+	r := loc.Xyxy(0, 0, 100, 50)
+	tests := []struct {
+		mode      loc.WritingMode
+		got, rest loc.Rect[int]
+	}{
+		{loc.HorizontalLTR, loc.Xyxy(0, 0, 30, 50), loc.Xyxy(30, 0, 100, 50)},
+		{loc.HorizontalRTL, loc.Xyxy(70, 0, 100, 50), loc.Xyxy(0, 0, 70, 50)},
+		{loc.VerticalRL, loc.Xyxy(0, 0, 100, 30), loc.Xyxy(0, 30, 100, 50)},
+		{loc.VerticalLR, loc.Xyxy(0, 0, 100, 30), loc.Xyxy(0, 30, 100, 50)},
+	}
+	for _, tt := range tests {
+		got, rest := r.Logical(tt.mode).CutInlineStart(30)
+		if got != tt.got || rest != tt.rest {
+			t.Errorf("mode %d: CutInlineStart mismatch, want %v %v, got %v %v", tt.mode, tt.got, tt.rest, got, rest)
+		}
+	}
+
+	got, rest := r.Logical(loc.VerticalRL).CutBlockStart(20)
+	if want := loc.Xyxy(80, 0, 100, 50); got != want || rest != loc.Xyxy(0, 0, 80, 50) {
+		t.Errorf("vertical-rl CutBlockStart mismatch, want %v, got %v %v", want, got, rest)
+	}
+	got, _ = r.Logical(loc.HorizontalRTL).CutInlineEnd(200)
+	if got != r {
+		t.Errorf("rtl CutInlineEnd over mismatch, want %v, got %v", r, got)
+	}
+}
+
+func TestLogicalRect_Anchor(t *testing.T) {
+	r := loc.Xyxy(0, 0, 100, 50)
+	tests := []struct {
+		mode loc.WritingMode
+		want loc.Point[int]
+	}{
+		{loc.HorizontalLTR, loc.Xy(0, 0)},
+		{loc.HorizontalRTL, loc.Xy(100, 0)},
+		{loc.VerticalRL, loc.Xy(100, 0)},
+		{loc.VerticalLR, loc.Xy(0, 0)},
+	}
+	for _, tt := range tests {
+		if got := r.Logical(tt.mode).Anchor(0, 0); got != tt.want {
+			t.Errorf("mode %d: Anchor(0, 0) mismatch, want %v, got %v", tt.mode, tt.want, got)
+		}
+	}
+	box := loc.Xyxy(0, 0, 10, 10).Logical(loc.HorizontalRTL)
+	if got, want := box.Within(r, 0, 0), loc.Xyxy(90, 0, 100, 10); got != want {
+		t.Errorf("rtl Within mismatch, want %v, got %v", want, got)
+	}
+	if got, want := box.Align(loc.Xy(50, 50), 0, 1), loc.Xyxy(40, 40, 50, 50); got != want {
+		t.Errorf("rtl Align mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestLogicalRect_Split(t *testing.T) {
+	r := loc.Xyxy(0, 0, 90, 60)
+	got := r.Logical(loc.HorizontalRTL).SplitInline(3, 0)
+	want := []loc.Rect[int]{loc.Xyxy(60, 0, 90, 60), loc.Xyxy(30, 0, 60, 60), loc.Xyxy(0, 0, 30, 60)}
+	if !slices.Equal(want, got) {
+		t.Errorf("rtl SplitInline mismatch, want %v, got %v", want, got)
+	}
+	got = r.Logical(loc.VerticalRL).SplitBlock(3, 0)
+	if !slices.Equal(want, got) {
+		t.Errorf("vertical-rl SplitBlock mismatch, want %v, got %v", want, got)
+	}
+	got = r.Logical(loc.HorizontalRTL).TracksInline(0, loc.Fixed(10), loc.Fr[int](1))
+	want = []loc.Rect[int]{loc.Xyxy(80, 0, 90, 60), loc.Xyxy(0, 0, 80, 60)}
+	if !slices.Equal(want, got) {
+		t.Errorf("rtl TracksInline mismatch, want %v, got %v", want, got)
+	}
+	got = r.Logical(loc.VerticalLR).TracksInline(0, loc.Fixed(10), loc.Fr[int](1))
+	want = []loc.Rect[int]{loc.Xyxy(0, 0, 90, 10), loc.Xyxy(0, 10, 90, 60)}
+	if !slices.Equal(want, got) {
+		t.Errorf("vertical-lr TracksInline mismatch, want %v, got %v", want, got)
+	}
+	if l := r.Logical(loc.VerticalRL); l.InlineSize() != 60 || l.BlockSize() != 90 {
+		t.Errorf("vertical-rl sizes mismatch, got %d, %d", l.InlineSize(), l.BlockSize())
+	}
+}
+
+func TestLogicalRect_TracksOverflow(t *testing.T) {
+	r := loc.Xyxy(0, 0, 10, 10)
+	// Overflowing tracks spill past the end edge, which is Min.X here.
+	want := []loc.Rect[int]{loc.Xyxy(2, 0, 10, 10), loc.Xyxy(-6, 0, 2, 10)}
+	got := r.Logical(loc.HorizontalRTL).TracksInline(0, loc.Fixed(8), loc.Fixed(8))
+	if !slices.Equal(want, got) {
+		t.Errorf("rtl TracksInline overflow mismatch, want %v, got %v", want, got)
+	}
+	got = r.Logical(loc.VerticalRL).TracksBlock(0, loc.Fixed(8), loc.Fixed(8))
+	if !slices.Equal(want, got) {
+		t.Errorf("vertical-rl TracksBlock overflow mismatch, want %v, got %v", want, got)
+	}
+	got = r.Logical(loc.VerticalRL).TracksInline(0, loc.Fixed(8), loc.Fixed(8))
+	want = []loc.Rect[int]{loc.Xyxy(0, 0, 10, 8), loc.Xyxy(0, 8, 10, 16)}
+	if !slices.Equal(want, got) {
+		t.Errorf("vertical-rl TracksInline overflow mismatch, want %v, got %v", want, got)
+	}
+}
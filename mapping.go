@@ -0,0 +1,148 @@
+package loc
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// A FitMode determines how a source rectangle is scaled into a destination.
+type FitMode int
+
+const (
+	// FitStretch scales each axis independently to fill the destination.
+	FitStretch FitMode = iota
+	// FitContain scales uniformly so the source fits inside the destination,
+	// leaving bars (letterboxing) on one axis.
+	FitContain
+	// FitCover scales uniformly so the source covers the destination,
+	// cropping it on one axis.
+	FitCover
+	// FitNone does not scale.
+	FitNone
+	// FitInteger scales uniformly by the largest whole factor that fits, but
+	// never below 1, so a destination smaller than the source crops it.
+	FitInteger
+	// FitPixelPerfect scales uniformly by the largest whole factor that fits,
+	// or by 1/n for the smallest n that fits when the destination is smaller
+	// than the source, and places the result on whole pixels.
+	FitPixelPerfect
+)
+
+// Scale returns the horizontal and vertical scale factors that mode m
+// applies to fit a source of size src into a destination of size dst.
+func (m FitMode) Scale(src, dst Point[float64]) Point[float64] {
+	if src.X <= 0 || src.Y <= 0 {
+		return Xy(1.0, 1.0)
+	}
+	sx, sy := dst.X/src.X, dst.Y/src.Y
+	s := min(sx, sy)
+	switch m {
+	case FitStretch:
+		return Xy(sx, sy)
+	case FitCover:
+		s = max(sx, sy)
+	case FitNone:
+		s = 1
+	case FitInteger:
+		s = max(math.Floor(s), 1)
+	case FitPixelPerfect:
+		switch {
+		case s >= 1:
+			s = math.Floor(s)
+		case s <= 0:
+			s = 1
+		default:
+			s = 1 / math.Ceil(1/s)
+		}
+	}
+	return Xy(s, s)
+}
+
+// A Mapping maps points and rectangles from a source space into a
+// destination space, such as from world to screen coordinates, and back.
+type Mapping[S ng.Scalar] struct {
+	fwd, inv Affine[S]
+	view     Rect[S]
+}
+
+// NewMapping returns the mapping of src into dst with the given fit mode.
+// When the scaled source does not exactly fill dst, it is placed at the
+// relative position (rx, ry) as with Point.Align: 0, 0 aligns the top-left
+// corners and 0.5, 0.5 centers it.
+//
+// NewMapping reports false if the mapping cannot be inverted, as when dst has
+// zero width or height and mode scales src down to nothing. The returned
+// mapping still maps forward, but InvPoint, InvRect and Inverse are
+// meaningless.
+func NewMapping[S ng.Scalar](src, dst Rect[S], mode FitMode, rx, ry float64) (Mapping[S], bool) {
+	sw, sh := float64(src.Dx()), float64(src.Dy())
+	dw, dh := float64(dst.Dx()), float64(dst.Dy())
+	scale := mode.Scale(Xy(sw, sh), Xy(dw, dh))
+	sx, sy := scale.X, scale.Y
+	x0 := float64(dst.Min.X) + rx*(dw-sw*sx)
+	y0 := float64(dst.Min.Y) + ry*(dh-sh*sy)
+	if mode == FitPixelPerfect {
+		x0, y0 = math.Floor(x0), math.Floor(y0)
+	}
+
+	fwd := Affine[S]{
+		A: sx, D: sy,
+		E: x0 - sx*float64(src.Min.X),
+		F: y0 - sy*float64(src.Min.Y),
+	}
+	inv, ok := fwd.Invert()
+	return Mapping[S]{fwd: fwd, inv: inv, view: fwd.ApplyRect(src)}, ok
+}
+
+// Point maps p from the source space to the destination space.
+func (m Mapping[S]) Point(p Point[S]) Point[S] {
+	return m.fwd.ApplyPoint(p)
+}
+
+// Rect maps r from the source space to the destination space.
+func (m Mapping[S]) Rect(r Rect[S]) Rect[S] {
+	return m.fwd.ApplyRect(r)
+}
+
+// InvPoint maps p from the destination space back to the source space,
+// as needed for mouse picking.
+func (m Mapping[S]) InvPoint(p Point[S]) Point[S] {
+	return m.inv.ApplyPoint(p)
+}
+
+// InvRect maps r from the destination space back to the source space.
+func (m Mapping[S]) InvRect(r Rect[S]) Rect[S] {
+	return m.inv.ApplyRect(r)
+}
+
+// Inverse returns the mapping from the destination space to the source space.
+func (m Mapping[S]) Inverse() Mapping[S] {
+	return Mapping[S]{fwd: m.inv, inv: m.fwd, view: m.inv.ApplyRect(m.view)}
+}
+
+// Then returns the mapping that applies m and then n, such as world to
+// camera followed by camera to screen.
+func (m Mapping[S]) Then(n Mapping[S]) Mapping[S] {
+	return Mapping[S]{
+		fwd:  m.fwd.Then(n.fwd),
+		inv:  n.inv.Then(m.inv),
+		view: n.fwd.ApplyRect(m.view),
+	}
+}
+
+// Viewport returns the source rectangle mapped into the destination space.
+// It is smaller than the destination with FitContain and larger with FitCover.
+func (m Mapping[S]) Viewport() Rect[S] {
+	return m.view
+}
+
+// Scale returns the scale factors of m.
+func (m Mapping[S]) Scale() (sx, sy float64) {
+	return m.fwd.A, m.fwd.D
+}
+
+// Affine returns m as an affine transform.
+func (m Mapping[S]) Affine() Affine[S] {
+	return m.fwd
+}
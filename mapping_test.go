@@ -0,0 +1,94 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestMapping_Modes(t *testing.T) {
+	world := loc.Xyxy(0, 0, 400, 300)
+	screen := loc.Xyxy(0, 0, 1000, 500)
+	tests := []struct {
+		mode loc.FitMode
+		want loc.Rect[int]
+	}{
+		{loc.FitStretch, loc.Xyxy(0, 0, 1000, 500)},
+		{loc.FitContain, loc.Xyxy(166, 0, 834, 500)},
+		{loc.FitCover, loc.Xyxy(0, -125, 1000, 625)},
+		{loc.FitNone, loc.Xyxy(300, 100, 700, 400)},
+	}
+	for _, tt := range tests {
+		m, ok := loc.NewMapping(world, screen, tt.mode, 0.5, 0.5)
+		if !ok {
+			t.Errorf("mode %d: mapping should be invertible", tt.mode)
+		}
+		if got := m.Viewport(); got != tt.want {
+			t.Errorf("mode %d: Viewport mismatch, want %v, got %v", tt.mode, tt.want, got)
+		}
+	}
+}
+
+func TestMapping_RoundTrip(t *testing.T) {
+	world := loc.Xyxy(-100.0, -100, 100, 100)
+	screen := loc.Xyxy(0.0, 0, 800, 600)
+	m, _ := loc.NewMapping(world, screen, loc.FitContain, 0, 1)
+	if got, want := m.Point(loc.Xy(-100.0, 100)), loc.Xy(0.0, 600); got != want {
+		t.Errorf("Point mismatch, want %v, got %v", want, got)
+	}
+	mouse := loc.Xy(300.0, 450)
+	if got := m.Point(m.InvPoint(mouse)); got != mouse {
+		t.Errorf("InvPoint round trip mismatch, want %v, got %v", mouse, got)
+	}
+	if got := m.Inverse().Rect(m.Viewport()); got != world {
+		t.Errorf("Inverse viewport mismatch, want %v, got %v", world, got)
+	}
+}
+
+func TestMapping_Then(t *testing.T) {
+	world := loc.Xyxy(0.0, 0, 1000, 1000)
+	camera := loc.Xyxy(200.0, 200, 400, 400)
+	screen := loc.Xyxy(0.0, 0, 800, 800)
+	worldToCamera, _ := loc.NewMapping(camera, camera, loc.FitNone, 0, 0)
+	cameraToScreen, _ := loc.NewMapping(camera, screen, loc.FitStretch, 0, 0)
+	m := worldToCamera.Then(cameraToScreen)
+	if got, want := m.Point(loc.Xy(300.0, 250)), loc.Xy(400.0, 200); got != want {
+		t.Errorf("Then Point mismatch, want %v, got %v", want, got)
+	}
+	if got, want := m.InvPoint(loc.Xy(400.0, 200)), loc.Xy(300.0, 250); got != want {
+		t.Errorf("Then InvPoint mismatch, want %v, got %v", want, got)
+	}
+	if got, want := m.Rect(world), loc.Xyxy(-800.0, -800, 3200, 3200); got != want {
+		t.Errorf("Then Rect mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestMapping_Singular(t *testing.T) {
+	world := loc.Xyxy(0.0, 0, 400, 300)
+	for _, mode := range []loc.FitMode{loc.FitStretch, loc.FitContain} {
+		m, ok := loc.NewMapping(world, loc.Xyxy(0.0, 0, 0, 500), mode, 0, 0)
+		if ok {
+			t.Errorf("mode %d: mapping into an empty rect should not be invertible", mode)
+		}
+		if got := m.Point(loc.Xy(400.0, 300)); got.X != 0 {
+			t.Errorf("mode %d: forward Point should collapse X, got %v", mode, got)
+		}
+	}
+	if _, ok := loc.NewMapping(world, loc.Xyxy(0.0, 0, 0, 500), loc.FitCover, 0, 0); !ok {
+		t.Errorf("cover into a zero-width rect should still be invertible")
+	}
+}
+
+func TestMapping_PixelPerfect(t *testing.T) {
+	// 3x of 100 centered in 301 would start at 0.5 without flooring.
+	m, _ := loc.NewMapping(loc.Xyxy(0.0, 0, 100, 100), loc.Xyxy(0.0, 0, 301, 301), loc.FitPixelPerfect, 0.5, 0.5)
+	if sx, sy := m.Scale(); sx != 3 || sy != 3 {
+		t.Errorf("Scale mismatch, got %v, %v", sx, sy)
+	}
+	if got, want := m.Viewport(), loc.Xyxy(0.0, 0, 300, 300); got != want {
+		t.Errorf("Viewport mismatch, want %v, got %v", want, got)
+	}
+	if got, want := m.Point(loc.Xy(1.0, 1.0)), loc.Xy(3.0, 3.0); got != want {
+		t.Errorf("Point mismatch, want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,147 @@
+package loc
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Metric selects how distances are measured.
+type Metric int
+
+const (
+	Euclidean Metric = iota // straight-line distance
+	Manhattan               // sum of the horizontal and vertical distances
+	Chebyshev               // larger of the horizontal and vertical distances
+)
+
+// measure returns the length of the vector (dx, dy) under m.
+func (m Metric) measure(dx, dy float64) float64 {
+	dx, dy = math.Abs(dx), math.Abs(dy)
+	switch m {
+	case Manhattan:
+		return dx + dy
+	case Chebyshev:
+		return max(dx, dy)
+	}
+	return math.Hypot(dx, dy)
+}
+
+// Dist returns the distance from p to the nearest point of r under m, or 0
+// if p is in r. Distances are measured to the closure of r, so a rectangle
+// with zero width or height behaves as a line or a point.
+func (p Point[S]) Dist(r Rect[S], m Metric) float64 {
+	dx, dy := pointGaps(p, r)
+	return m.measure(dx, dy)
+}
+
+// Dist returns the distance between the nearest points of r and s under m,
+// or 0 if they overlap or touch.
+func (r Rect[S]) Dist(s Rect[S], m Metric) float64 {
+	dx := gap(float64(r.Min.X), float64(r.Max.X), float64(s.Min.X), float64(s.Max.X))
+	dy := gap(float64(r.Min.Y), float64(r.Max.Y), float64(s.Min.Y), float64(s.Max.Y))
+	return m.measure(max(dx, 0), max(dy, 0))
+}
+
+// distSq returns the squared Euclidean distance from p to the nearest point
+// of r, computed in float64.
+func distSq[S ng.Scalar](p Point[S], r Rect[S]) float64 {
+	dx, dy := pointGaps(p, r)
+	return dx*dx + dy*dy
+}
+
+// pointGaps returns the horizontal and vertical distances from p to the
+// closure of r.
+func pointGaps[S ng.Scalar](p Point[S], r Rect[S]) (dx, dy float64) {
+	switch {
+	case p.X < r.Min.X:
+		dx = float64(r.Min.X) - float64(p.X)
+	case p.X > r.Max.X:
+		dx = float64(p.X) - float64(r.Max.X)
+	}
+	switch {
+	case p.Y < r.Min.Y:
+		dy = float64(r.Min.Y) - float64(p.Y)
+	case p.Y > r.Max.Y:
+		dy = float64(p.Y) - float64(r.Max.Y)
+	}
+	return dx, dy
+}
+
+// GapX returns the signed horizontal gap between r and s: the distance
+// between their facing edges if they are apart, or minus the width of their
+// horizontal overlap. For unsigned types a negative gap wraps around.
+func (r Rect[S]) GapX(s Rect[S]) S {
+	return gap(r.Min.X, r.Max.X, s.Min.X, s.Max.X)
+}
+
+// GapY is like GapX, but returns the vertical gap.
+func (r Rect[S]) GapY(s Rect[S]) S {
+	return gap(r.Min.Y, r.Max.Y, s.Min.Y, s.Max.Y)
+}
+
+// gap returns the signed gap between [amin, amax) and [bmin, bmax), without
+// forming a negative intermediate when they are apart.
+func gap[S ng.Scalar](amin, amax, bmin, bmax S) S {
+	switch {
+	case amax <= bmin:
+		return bmin - amax
+	case bmax <= amin:
+		return amin - bmax
+	}
+	return -(min(amax, bmax) - max(amin, bmin))
+}
+
+// Clamp returns the point of r nearest to p. For integer types the result is
+// in r, at most Max-1, or r.Min if r is empty; for floats it lies in the
+// closure of r.
+func (p Point[S]) Clamp(r Rect[S]) Point[S] {
+	hi := r.Max
+	if isInt[S]() {
+		if r.Empty() {
+			return r.Min
+		}
+		hi = Point[S]{X: r.Max.X - 1, Y: r.Max.Y - 1}
+	}
+	return Point[S]{
+		X: max(min(p.X, hi.X), r.Min.X),
+		Y: max(min(p.Y, hi.Y), r.Min.Y),
+	}
+}
+
+// Edge names an edge of a rectangle.
+type Edge int
+
+const (
+	EdgeLeft Edge = iota
+	EdgeTop
+	EdgeRight
+	EdgeBottom
+)
+
+// Edge returns edge e of r as a segment, running clockwise on screen like
+// Rect.Polygon.
+func (r Rect[S]) Edge(e Edge) Segment[S] {
+	p := r.Polygon()
+	switch e {
+	case EdgeTop:
+		return Segment[S]{A: p[0], B: p[1]}
+	case EdgeRight:
+		return Segment[S]{A: p[1], B: p[2]}
+	case EdgeBottom:
+		return Segment[S]{A: p[2], B: p[3]}
+	}
+	return Segment[S]{A: p[3], B: p[0]}
+}
+
+// ClosestEdge returns the edge of r nearest to p and its Euclidean distance
+// from p. Ties go to the first of left, top, right and bottom.
+func (r Rect[S]) ClosestEdge(p Point[S]) (Edge, float64) {
+	best, dist := EdgeLeft, math.Inf(1)
+	for e := EdgeLeft; e <= EdgeBottom; e++ {
+		if d := r.Edge(e).Dist(p); d < dist {
+			best, dist = e, d
+		}
+	}
+	return best, dist
+}
@@ -0,0 +1,117 @@
+package loc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestPoint_Dist(t *testing.T) {
+	r := loc.Xyxy(0, 0, 10, 10)
+	tests := []struct {
+		p              loc.Point[int]
+		euc, man, cheb float64
+	}{
+		{loc.Xy(5, 5), 0, 0, 0},
+		{loc.Xy(10, 10), 0, 0, 0}, // on the closure
+		{loc.Xy(13, 14), 5, 7, 4},
+		{loc.Xy(-3, 5), 3, 3, 3},
+		{loc.Xy(5, -2), 2, 2, 2},
+	}
+	for _, tt := range tests {
+		for m, want := range map[loc.Metric]float64{loc.Euclidean: tt.euc, loc.Manhattan: tt.man, loc.Chebyshev: tt.cheb} {
+			if got := tt.p.Dist(r, m); got != want {
+				t.Errorf("%v.Dist(%v, %d) = %v, want %v", tt.p, r, m, got, want)
+			}
+		}
+	}
+}
+
+func TestRect_Dist(t *testing.T) {
+	a := loc.Xywh[uint](0, 0, 10, 10)
+	tests := []struct {
+		b              loc.Rect[uint]
+		euc, man, cheb float64
+	}{
+		{loc.Xywh[uint](5, 5, 10, 10), 0, 0, 0},
+		{loc.Xywh[uint](10, 0, 10, 10), 0, 0, 0},
+		{loc.Xywh[uint](13, 14, 5, 5), 5, 7, 4},
+		{loc.Xywh[uint](0, 20, 5, 5), 10, 10, 10},
+	}
+	for _, tt := range tests {
+		for m, want := range map[loc.Metric]float64{loc.Euclidean: tt.euc, loc.Manhattan: tt.man, loc.Chebyshev: tt.cheb} {
+			if got := a.Dist(tt.b, m); got != want {
+				t.Errorf("Dist(%v, %d) = %v, want %v", tt.b, m, got, want)
+			}
+			if got := tt.b.Dist(a, m); got != want {
+				t.Errorf("reversed Dist(%v, %d) = %v, want %v", tt.b, m, got, want)
+			}
+		}
+	}
+}
+
+func TestRect_Gap(t *testing.T) {
+	a := loc.Xyxy(0, 0, 10, 10)
+	tests := []struct {
+		b          loc.Rect[int]
+		gapX, gapY int
+	}{
+		{loc.Xyxy(15, 2, 20, 4), 5, -2},
+		{loc.Xyxy(-8, 12, -3, 20), 3, 2},
+		{loc.Xyxy(7, 3, 20, 20), -3, -7},
+		{loc.Xyxy(10, 10, 12, 12), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := a.GapX(tt.b); got != tt.gapX {
+			t.Errorf("GapX(%v) = %d, want %d", tt.b, got, tt.gapX)
+		}
+		if got := a.GapY(tt.b); got != tt.gapY {
+			t.Errorf("GapY(%v) = %d, want %d", tt.b, got, tt.gapY)
+		}
+	}
+	if got := loc.Xyxy[uint](0, 0, 2, 2).GapX(loc.Xyxy[uint](5, 0, 7, 2)); got != 3 {
+		t.Errorf("unsigned GapX mismatch, got %d", got)
+	}
+}
+
+func TestPoint_Clamp(t *testing.T) {
+	r := loc.Xyxy(0, 0, 10, 10)
+	if got := loc.Xy(15, -3).Clamp(r); got != loc.Xy(9, 0) || !got.In(r) {
+		t.Errorf("Clamp mismatch, got %v", got)
+	}
+	if got := loc.Xy(4, 5).Clamp(r); got != loc.Xy(4, 5) {
+		t.Errorf("Clamp inside mismatch, got %v", got)
+	}
+	if got := loc.Xy(15.0, -3.0).Clamp(loc.Xyxy(0.0, 0.0, 10.0, 10.0)); got != loc.Xy(10.0, 0.0) {
+		t.Errorf("float Clamp mismatch, got %v", got)
+	}
+	if got := loc.Xy[uint](5, 5).Clamp(loc.Rect[uint]{}); got != (loc.Point[uint]{}) {
+		t.Errorf("empty Clamp mismatch, got %v", got)
+	}
+}
+
+func TestRect_ClosestEdge(t *testing.T) {
+	r := loc.Xyxy(0, 0, 10, 20)
+	tests := []struct {
+		p    loc.Point[int]
+		edge loc.Edge
+		dist float64
+	}{
+		{loc.Xy(2, 10), loc.EdgeLeft, 2},
+		{loc.Xy(5, 1), loc.EdgeTop, 1},
+		{loc.Xy(8, 10), loc.EdgeRight, 2},
+		{loc.Xy(5, 25), loc.EdgeBottom, 5},
+		{loc.Xy(13, 24), loc.EdgeRight, 5},
+		{loc.Xy(5, 5), loc.EdgeLeft, 5}, // tie with top and right
+	}
+	for _, tt := range tests {
+		edge, dist := r.ClosestEdge(tt.p)
+		if edge != tt.edge || math.Abs(dist-tt.dist) > 1e-9 {
+			t.Errorf("ClosestEdge(%v) = %d %v, want %d %v", tt.p, edge, dist, tt.edge, tt.dist)
+		}
+	}
+	if got := r.Edge(loc.EdgeBottom); got != loc.Seg(loc.Xy(10, 20), loc.Xy(0, 20)) {
+		t.Errorf("Edge mismatch, got %v", got)
+	}
+}
@@ -0,0 +1,196 @@
+package loc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// An OBB is an oriented bounding box: a rectangle with half-extents Half
+// centered at Center and rotated by Angle radians about it. As with Affine,
+// positive angles are clockwise on screen. The fields are float64 whatever S
+// is, so that odd integer sizes keep their exact center; S is the type of the
+// points, rectangles and vectors it works with.
+type OBB[S ng.Scalar] struct {
+	Center Point[float64]
+	Half   Point[float64]
+	Angle  float64
+}
+
+// OBB returns r as an oriented bounding box with angle 0.
+func (r Rect[S]) OBB() OBB[S] {
+	min, max := r.Min.Float64(), r.Max.Float64()
+	return OBB[S]{
+		Center: Xy((min.X+max.X)/2, (min.Y+max.Y)/2),
+		Half:   Xy((max.X-min.X)/2, (max.Y-min.Y)/2),
+	}
+}
+
+// String returns a string representation of o like "(1,2)±(3,4)@0.5".
+func (o OBB[S]) String() string {
+	return fmt.Sprintf("%v±%v@%v", o.Center, o.Half, o.Angle)
+}
+
+// Rotate returns o rotated by rad radians about its center.
+func (o OBB[S]) Rotate(rad float64) OBB[S] {
+	o.Angle += rad
+	return o
+}
+
+// Add returns o translated by p.
+func (o OBB[S]) Add(p Point[S]) OBB[S] {
+	o.Center = o.Center.Add(p.Float64())
+	return o
+}
+
+// Axes returns the unit vectors of the local X and Y axes of o.
+func (o OBB[S]) Axes() (x, y Point[float64]) {
+	sin, cos := math.Sincos(o.Angle)
+	return Xy(cos, sin), Xy(-sin, cos)
+}
+
+// Corners returns the corners of o in the order of Rect.Polygon: the local
+// top-left, top-right, bottom-right and bottom-left.
+func (o OBB[S]) Corners() [4]Point[float64] {
+	ax, ay := o.Axes()
+	hx, hy := ax.Mul(o.Half.X), ay.Mul(o.Half.Y)
+	c := o.Center
+	return [4]Point[float64]{
+		c.Sub(hx).Sub(hy),
+		c.Add(hx).Sub(hy),
+		c.Add(hx).Add(hy),
+		c.Sub(hx).Add(hy),
+	}
+}
+
+// Polygon returns the corners of o as a polygon, rounded to the nearest
+// integer for integer types.
+func (o OBB[S]) Polygon() Polygon[S] {
+	p := make(Polygon[S], 4)
+	for i, c := range o.Corners() {
+		p[i] = Point[S]{X: roundS[S](snap(c.X)), Y: roundS[S](snap(c.Y))}
+	}
+	return p
+}
+
+// Bounds returns the smallest rectangle containing o, rounded outward for
+// integer types.
+func (o OBB[S]) Bounds() Rect[S] {
+	ax, ay := o.Axes()
+	ex := math.Abs(ax.X)*o.Half.X + math.Abs(ay.X)*o.Half.Y
+	ey := math.Abs(ax.Y)*o.Half.X + math.Abs(ay.Y)*o.Half.Y
+	return Xyxy(
+		floorS[S](snap(o.Center.X-ex)), floorS[S](snap(o.Center.Y-ey)),
+		ceilS[S](snap(o.Center.X+ex)), ceilS[S](snap(o.Center.Y+ey)),
+	)
+}
+
+// Contains reports whether p is inside o. Like Point.In, the local left and
+// top edges are inside and the right and bottom edges are not, so
+// r.OBB().Contains(p) == p.In(r).
+func (o OBB[S]) Contains(p Point[S]) bool {
+	ax, ay := o.Axes()
+	d := p.Float64().Sub(o.Center)
+	lx, ly := snap(dot(d, ax)*2)/2, snap(dot(d, ay)*2)/2
+	return -o.Half.X <= lx && lx < o.Half.X && -o.Half.Y <= ly && ly < o.Half.Y
+}
+
+// Overlaps reports whether o and p have a non-empty intersection.
+func (o OBB[S]) Overlaps(p OBB[S]) bool {
+	_, ok := o.Penetration(p)
+	return ok
+}
+
+// OverlapsRect reports whether o and r have a non-empty intersection.
+func (o OBB[S]) OverlapsRect(r Rect[S]) bool {
+	_, ok := o.PenetrationRect(r)
+	return ok
+}
+
+// OverlapsPolygon reports whether o and the convex polygon p have a non-empty
+// intersection. A polygon with zero area overlaps nothing; the result for a
+// non-convex polygon is undefined.
+func (o OBB[S]) OverlapsPolygon(p Polygon[S]) bool {
+	_, ok := o.PenetrationPolygon(p)
+	return ok
+}
+
+// Penetration returns the minimum translation vector by which o must move to
+// stop overlapping p, and false if they do not overlap. As with Overlaps,
+// touching is not overlapping. For integer types the vector is rounded away
+// from zero.
+func (o OBB[S]) Penetration(p OBB[S]) (Point[S], bool) {
+	a, b := o.Corners(), p.Corners()
+	return sat[S](a[:], b[:])
+}
+
+// PenetrationRect is like Penetration, but against the rectangle r.
+func (o OBB[S]) PenetrationRect(r Rect[S]) (Point[S], bool) {
+	if r.Empty() {
+		return Point[S]{}, false
+	}
+	a, b := o.Corners(), r.OBB().Corners()
+	return sat[S](a[:], b[:])
+}
+
+// PenetrationPolygon is like Penetration, but against the polygon p, which
+// must be convex; the result for a non-convex polygon is undefined. A polygon
+// with zero area, such as a collinear one, never overlaps.
+func (o OBB[S]) PenetrationPolygon(p Polygon[S]) (Point[S], bool) {
+	if len(p) < 3 {
+		return Point[S]{}, false
+	}
+	a := o.Corners()
+	b := make([]Point[float64], len(p))
+	for i, v := range p {
+		b[i] = v.Float64()
+	}
+	return sat[S](a[:], b)
+}
+
+// sat finds the minimum translation vector that pushes the convex polygon a
+// out of the convex polygon b with the separating axis theorem. Polygons with
+// zero area, such as collinear ones, overlap nothing.
+func sat[S ng.Scalar](a, b []Point[float64]) (Point[S], bool) {
+	if Polygon[float64](a).SignedArea() == 0 || Polygon[float64](b).SignedArea() == 0 {
+		return Point[S]{}, false
+	}
+	best, depth := Point[float64]{}, math.Inf(1)
+	for _, poly := range [][]Point[float64]{a, b} {
+		for i, v := range poly {
+			e := poly[(i+1)%len(poly)].Sub(v)
+			if e == (Point[float64]{}) {
+				continue
+			}
+			n := unit(Xy(-e.Y, e.X))
+			amin, amax := project(a, n)
+			bmin, bmax := project(b, n)
+			pos, neg := snap(bmax-amin), snap(amax-bmin) // push a along +n or -n
+			if pos <= 0 || neg <= 0 {
+				return Point[S]{}, false
+			}
+			if pos < depth {
+				best, depth = n, pos
+			}
+			if neg < depth {
+				best, depth = n.Mul(-1), neg
+			}
+		}
+	}
+	if math.IsInf(depth, 1) {
+		return Point[S]{}, false
+	}
+	v := best.Mul(depth)
+	return awayPoint[S](Xy(snap(v.X), snap(v.Y))), true
+}
+
+// project returns the extent of the points along the unit vector n.
+func project(ps []Point[float64], n Point[float64]) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range ps {
+		d := dot(p, n)
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
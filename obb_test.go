@@ -0,0 +1,93 @@
+package loc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestRect_OBB(t *testing.T) {
+	r := loc.Xywh(1, 2, 3, 5)
+	o := r.OBB()
+	if o.Center != loc.Xy(2.5, 4.5) || o.Half != loc.Xy(1.5, 2.5) || o.Angle != 0 {
+		t.Errorf("OBB mismatch, got %v", o)
+	}
+	if got := o.Bounds(); got != r {
+		t.Errorf("Bounds mismatch, want %v, got %v", r, got)
+	}
+	for pt := range loc.Xywh(0, 0, 6, 9).Points() {
+		if got, want := o.Contains(pt), pt.In(r); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", pt, got, want)
+		}
+	}
+
+	d := loc.Xywh(-1, -1, 2, 2).OBB().Rotate(math.Pi / 4)
+	s := math.Sqrt2
+	if got, want := d.Bounds(), loc.Xyxy(-2, -2, 2, 2); got != want {
+		t.Errorf("rotated Bounds mismatch, want %v, got %v", want, got)
+	}
+	if got := loc.Xywh(-1.0, -1.0, 2.0, 2.0).OBB().Rotate(math.Pi / 4).Bounds(); math.Abs(got.Max.X-s) > 1e-9 {
+		t.Errorf("rotated float Bounds mismatch, got %v", got)
+	}
+	if got := d.Polygon(); len(got) != 4 || got[0] != loc.Xy(0, -1) {
+		t.Errorf("rotated Polygon mismatch, got %v", got)
+	}
+	if !d.Contains(loc.Xy(0, 1)) || d.Contains(loc.Xy(1, 1)) {
+		t.Errorf("rotated Contains mismatch")
+	}
+}
+
+func TestOBB_Penetration(t *testing.T) {
+	a := loc.Xywh(0.0, 0.0, 4.0, 4.0).OBB()
+
+	// Axis-aligned boxes agree with Rect.Overlaps, including touching.
+	if _, ok := a.Penetration(loc.Xywh(4.0, 0.0, 4.0, 4.0).OBB()); ok {
+		t.Errorf("touching boxes should not overlap")
+	}
+	v, ok := a.Penetration(loc.Xywh(3.0, 1.0, 4.0, 4.0).OBB())
+	if !ok || v != loc.Xy(-1.0, 0.0) {
+		t.Errorf("Penetration mismatch, got %v %v", v, ok)
+	}
+
+	// A diamond resting its tip one unit into the top of a box.
+	diamond := loc.Xywh(-1.0, -1.0, 2.0, 2.0).OBB().Rotate(math.Pi / 4).Add(loc.Xy(2.0, -0.5))
+	v, ok = diamond.PenetrationRect(loc.Xywh(0.0, 0.0, 4.0, 4.0))
+	if want := -(math.Sqrt2 - 0.5); !ok || math.Abs(v.X) > 1e-9 || math.Abs(v.Y-want) > 1e-9 {
+		t.Errorf("PenetrationRect mismatch, want (0,%v), got %v %v", want, v, ok)
+	}
+	if diamond.Add(v).OverlapsRect(loc.Xywh(0.0, 0.0, 4.0, 4.0)) {
+		t.Errorf("PenetrationRect %v does not separate", v)
+	}
+	if diamond.OverlapsRect(loc.Xywh(3.5, -1.5, 2.0, 1.0)) {
+		t.Errorf("rect beside the diamond's edge should not overlap")
+	}
+
+	// Rounded away from zero, integer vectors always separate.
+	b := loc.Xywh(0, 0, 10, 4).OBB().Rotate(0.3)
+	r := loc.Xywh(6, 3, 10, 10)
+	vi, ok := b.PenetrationRect(r)
+	if !ok || b.Add(vi).OverlapsRect(r) {
+		t.Errorf("integer PenetrationRect %v does not separate", vi)
+	}
+}
+
+func TestOBB_PenetrationPolygon(t *testing.T) {
+	tri := loc.Polygon[float64]{{0, 0}, {10, 0}, {0, 10}}
+	o := loc.Xywh(5.0, 5.0, 4.0, 4.0).OBB()
+	if o.OverlapsPolygon(tri) {
+		t.Errorf("box beyond the hypotenuse should not overlap")
+	}
+	o = o.Add(loc.Xy(-1.0, -1.0))
+	v, ok := o.PenetrationPolygon(tri)
+	if !ok || math.Abs(v.X-1) > 1e-9 || math.Abs(v.Y-1) > 1e-9 {
+		t.Errorf("PenetrationPolygon mismatch, got %v %v", v, ok)
+	}
+	if _, ok := o.PenetrationPolygon(tri[:2]); ok {
+		t.Errorf("degenerate polygon should not overlap")
+	}
+	line := loc.Polygon[float64]{{0, 0}, {5, 5}, {10, 10}}
+	if v, ok := o.PenetrationPolygon(line); ok {
+		t.Errorf("collinear polygon through the box should not overlap, got %v", v)
+	}
+}
@@ -0,0 +1,327 @@
+package loc
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A PackAlgorithm selects the strategy used by a Packer.
+type PackAlgorithm int
+
+const (
+	// PackMaxRects tracks all maximal free rectangles and places each item
+	// by best short side fit. It usually packs the tightest.
+	PackMaxRects PackAlgorithm = iota
+	// PackSkyline keeps the top edge of the packed items and places each
+	// item as low as possible. It is fast and suits items of similar height.
+	PackSkyline
+	// PackGuillotine cuts free rectangles in two with every placement and
+	// places each item by best area fit.
+	PackGuillotine
+)
+
+// A Packer packs rectangles of given sizes into bins for texture atlases.
+// Items are separated by Padding and may be rotated by 90 degrees if
+// AllowRotate is set. The output depends only on the input, so atlases built
+// from the same sizes are identical.
+type Packer[S ng.Scalar] struct {
+	Algorithm   PackAlgorithm
+	Bin         Rect[S]
+	Padding     S
+	AllowRotate bool
+}
+
+// A Placement is where a packed item was placed. Bin is the index of the bin,
+// or -1 if the item is larger than a bin. Rect excludes padding and has the
+// rotated size if Rotated is set.
+type Placement[S ng.Scalar] struct {
+	Bin     int
+	Rect    Rect[S]
+	Rotated bool
+}
+
+// binPacker places items into a single bin.
+type binPacker[S ng.Scalar] interface {
+	// find returns the best position for an item of size w×h, and a score
+	// where lower is better.
+	find(w, h S) (pos Point[S], score [2]float64, ok bool)
+	place(r Rect[S])
+}
+
+// Pack packs items of the given sizes. It returns the placement of each item
+// in input order and the occupancy of each bin, the fraction of its area
+// covered by items, which is 0 for a Bin with no area. Bins are opened as
+// needed.
+func (p Packer[S]) Pack(sizes []Point[S]) ([]Placement[S], []float64) {
+	// Pack large items first, by longer side then area.
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		sa, sb := sizes[a], sizes[b]
+		if la, lb := max(sa.X, sa.Y), max(sb.X, sb.Y); la != lb {
+			if la > lb {
+				return -1
+			}
+			return 1
+		}
+		if aa, ab := area(sa.AsSize()), area(sb.AsSize()); aa != ab {
+			if aa > ab {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+
+	placements := make([]Placement[S], len(sizes))
+	var bins []binPacker[S]
+	var used []float64
+	for _, i := range order {
+		w, h := sizes[i].X+p.Padding, sizes[i].Y+p.Padding
+		placements[i].Bin = -1
+		for b := 0; b <= len(bins); b++ {
+			fresh := b == len(bins)
+			if fresh {
+				bins = append(bins, p.newBin())
+				used = append(used, 0)
+			}
+			pos, rotated, ok := p.find(bins[b], w, h)
+			if !ok && fresh {
+				// The item can never fit; drop the bin opened for it.
+				bins = bins[:b]
+				used = used[:b]
+				break
+			}
+			if !ok {
+				continue
+			}
+			pw, ph := w, h
+			if rotated {
+				pw, ph = h, w
+			}
+			bins[b].place(Xywh(pos.X, pos.Y, pw, ph))
+			placements[i] = Placement[S]{
+				Bin:     b,
+				Rect:    Xywh(pos.X, pos.Y, pw-p.Padding, ph-p.Padding),
+				Rotated: rotated,
+			}
+			used[b] += area(placements[i].Rect)
+			break
+		}
+	}
+
+	if total := area(p.Bin); total > 0 {
+		for b := range used {
+			used[b] /= total
+		}
+	}
+	return placements, used
+}
+
+// find tries both orientations of a w×h item in bin.
+func (p Packer[S]) find(bin binPacker[S], w, h S) (Point[S], bool, bool) {
+	pos, score, ok := bin.find(w, h)
+	if p.AllowRotate && w != h {
+		rpos, rscore, rok := bin.find(h, w)
+		if rok && (!ok || less(rscore, score)) {
+			return rpos, true, true
+		}
+	}
+	return pos, false, ok
+}
+
+func (p Packer[S]) newBin() binPacker[S] {
+	// Extend the bin by the padding so items may touch its far edges.
+	bin := Rect[S]{Min: p.Bin.Min, Max: p.Bin.Max.Add(Xy(p.Padding, p.Padding))}
+	switch p.Algorithm {
+	case PackSkyline:
+		return &skyline[S]{bin: bin, segs: []skySeg[S]{{x: bin.Min.X, y: bin.Min.Y, w: bin.Dx()}}}
+	case PackGuillotine:
+		return &guillotine[S]{free: []Rect[S]{bin}}
+	}
+	return &maxRects[S]{free: []Rect[S]{bin}}
+}
+
+func less(a, b [2]float64) bool {
+	return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+}
+
+// maxRects implements the MaxRects algorithm with best short side fit.
+type maxRects[S ng.Scalar] struct {
+	free []Rect[S]
+}
+
+func (m *maxRects[S]) find(w, h S) (Point[S], [2]float64, bool) {
+	var best Point[S]
+	var bestScore [2]float64
+	found := false
+	for _, f := range m.free {
+		if f.Dx() < w || f.Dy() < h {
+			continue
+		}
+		dw, dh := float64(f.Dx()-w), float64(f.Dy()-h)
+		score := [2]float64{min(dw, dh), max(dw, dh)}
+		if !found || less(score, bestScore) {
+			best, bestScore, found = f.Min, score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+func (m *maxRects[S]) place(r Rect[S]) {
+	var next []Rect[S]
+	for _, f := range m.free {
+		if !f.Overlaps(r) {
+			next = append(next, f)
+			continue
+		}
+		// Keep the maximal parts of f around r.
+		if r.Min.X > f.Min.X {
+			next = append(next, Xyxy(f.Min.X, f.Min.Y, r.Min.X, f.Max.Y))
+		}
+		if r.Max.X < f.Max.X {
+			next = append(next, Xyxy(r.Max.X, f.Min.Y, f.Max.X, f.Max.Y))
+		}
+		if r.Min.Y > f.Min.Y {
+			next = append(next, Xyxy(f.Min.X, f.Min.Y, f.Max.X, r.Min.Y))
+		}
+		if r.Max.Y < f.Max.Y {
+			next = append(next, Xyxy(f.Min.X, r.Max.Y, f.Max.X, f.Max.Y))
+		}
+	}
+	// Prune free rectangles contained in others, keeping the first of equals.
+	m.free = m.free[:0]
+	for i, f := range next {
+		contained := false
+		for j, g := range next {
+			if i != j && fits(f, g) && (f != g || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			m.free = append(m.free, f)
+		}
+	}
+}
+
+type skySeg[S ng.Scalar] struct {
+	x, y, w S
+}
+
+// skyline implements the bottom-left skyline algorithm.
+type skyline[S ng.Scalar] struct {
+	bin  Rect[S]
+	segs []skySeg[S]
+}
+
+// fit returns the lowest y at which an item of width w fits starting at
+// segment i.
+func (s *skyline[S]) fit(i int, w, h S) (S, bool) {
+	x := s.segs[i].x
+	if x+w > s.bin.Max.X {
+		return 0, false
+	}
+	y := s.segs[i].y
+	for j := i; j < len(s.segs) && s.segs[j].x < x+w; j++ {
+		y = max(y, s.segs[j].y)
+	}
+	if y+h > s.bin.Max.Y {
+		return 0, false
+	}
+	return y, true
+}
+
+func (s *skyline[S]) find(w, h S) (Point[S], [2]float64, bool) {
+	var best Point[S]
+	var bestScore [2]float64
+	found := false
+	for i := range s.segs {
+		y, ok := s.fit(i, w, h)
+		if !ok {
+			continue
+		}
+		score := [2]float64{float64(y + h), float64(s.segs[i].x)}
+		if !found || less(score, bestScore) {
+			best, bestScore, found = Xy(s.segs[i].x, y), score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+func (s *skyline[S]) place(r Rect[S]) {
+	if r.Empty() {
+		return // zero-area items leave the skyline unchanged
+	}
+	i := slices.IndexFunc(s.segs, func(seg skySeg[S]) bool { return seg.x == r.Min.X })
+	segs := append([]skySeg[S](nil), s.segs[:i]...)
+	segs = append(segs, skySeg[S]{x: r.Min.X, y: r.Max.Y, w: r.Dx()})
+	for _, seg := range s.segs[i:] {
+		end := seg.x + seg.w
+		if end <= r.Max.X {
+			continue // covered by r
+		}
+		if seg.x < r.Max.X {
+			seg.w = end - r.Max.X
+			seg.x = r.Max.X
+		}
+		segs = append(segs, seg)
+	}
+	// Merge neighbours at the same height.
+	s.segs = segs[:1]
+	for _, seg := range segs[1:] {
+		last := &s.segs[len(s.segs)-1]
+		if last.y == seg.y {
+			last.w += seg.w
+		} else {
+			s.segs = append(s.segs, seg)
+		}
+	}
+}
+
+// guillotine implements the guillotine algorithm with best area fit and
+// splitting along the shorter leftover axis.
+type guillotine[S ng.Scalar] struct {
+	free []Rect[S]
+}
+
+func (g *guillotine[S]) find(w, h S) (Point[S], [2]float64, bool) {
+	var best Point[S]
+	var bestScore [2]float64
+	found := false
+	for _, f := range g.free {
+		if f.Dx() < w || f.Dy() < h {
+			continue
+		}
+		dw, dh := float64(f.Dx()-w), float64(f.Dy()-h)
+		score := [2]float64{area(f) - float64(w)*float64(h), min(dw, dh)}
+		if !found || less(score, bestScore) {
+			best, bestScore, found = f.Min, score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+func (g *guillotine[S]) place(r Rect[S]) {
+	i := slices.IndexFunc(g.free, func(f Rect[S]) bool { return f.Min == r.Min && fits(r, f) })
+	f := g.free[i]
+	g.free = slices.Delete(g.free, i, i+1)
+	var right, bottom Rect[S]
+	if f.Dx()-r.Dx() < f.Dy()-r.Dy() {
+		var top Rect[S]
+		top, bottom = f.CutY(r.Dy())
+		_, right = top.CutX(r.Dx())
+	} else {
+		var left Rect[S]
+		left, right = f.CutX(r.Dx())
+		_, bottom = left.CutY(r.Dy())
+	}
+	for _, part := range []Rect[S]{right, bottom} {
+		if !part.Empty() {
+			g.free = append(g.free, part)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package loc_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func checkPacking(t *testing.T, name string, p loc.Packer[int], sizes []loc.Point[int], placements []loc.Placement[int]) {
+	t.Helper()
+	for i, pl := range placements {
+		if pl.Bin < 0 {
+			continue
+		}
+		want := sizes[i]
+		if pl.Rotated {
+			want = loc.Xy(want.Y, want.X)
+		}
+		if pl.Rect.Size() != want {
+			t.Errorf("%s: item %d size mismatch, want %v, got %v", name, i, want, pl.Rect.Size())
+		}
+		if !pl.Rect.In(p.Bin) {
+			t.Errorf("%s: item %d at %v is outside the bin", name, i, pl.Rect)
+		}
+		padded := loc.Rect[int]{Min: pl.Rect.Min, Max: pl.Rect.Max.Add(loc.Xy(p.Padding, p.Padding))}
+		for j, other := range placements[i+1:] {
+			if other.Bin == pl.Bin && padded.Overlaps(other.Rect) {
+				t.Errorf("%s: item %d at %v overlaps item %d at %v", name, i, pl.Rect, i+1+j, other.Rect)
+			}
+		}
+	}
+}
+
+func TestPacker_Algorithms(t *testing.T) {
+	rng := rand.New(rand.NewPCG(12, 12))
+	sizes := make([]loc.Point[int], 200)
+	for i := range sizes {
+		sizes[i] = loc.Xy(4+rng.IntN(60), 4+rng.IntN(60))
+	}
+	algorithms := map[string]loc.PackAlgorithm{
+		"MaxRects":   loc.PackMaxRects,
+		"Skyline":    loc.PackSkyline,
+		"Guillotine": loc.PackGuillotine,
+	}
+	for name, alg := range algorithms {
+		for _, rotate := range []bool{false, true} {
+			p := loc.Packer[int]{Algorithm: alg, Bin: loc.Xyxy(0, 0, 256, 256), Padding: 2, AllowRotate: rotate}
+			placements, occupancy := p.Pack(sizes)
+			checkPacking(t, name, p, sizes, placements)
+			if len(occupancy) < 2 {
+				t.Errorf("%s: expected several bins, got %d", name, len(occupancy))
+			}
+			for b, o := range occupancy {
+				if o <= 0 || o > 1 {
+					t.Errorf("%s: bin %d occupancy %v out of range", name, b, o)
+				}
+			}
+			again, _ := p.Pack(sizes)
+			if !slices.Equal(placements, again) {
+				t.Errorf("%s: Pack is not deterministic", name)
+			}
+		}
+	}
+}
+
+func TestPacker_Exact(t *testing.T) {
+	sizes := []loc.Point[int]{loc.Xy(50, 100), loc.Xy(50, 50), loc.Xy(50, 50), loc.Xy(200, 10)}
+	for _, alg := range []loc.PackAlgorithm{loc.PackMaxRects, loc.PackSkyline, loc.PackGuillotine} {
+		p := loc.Packer[int]{Algorithm: alg, Bin: loc.Xyxy(0, 0, 100, 100)}
+		placements, occupancy := p.Pack(sizes)
+		checkPacking(t, "exact", p, sizes, placements)
+		for i := range 3 {
+			if placements[i].Bin != 0 {
+				t.Errorf("algorithm %d: item %d should be in bin 0, got %d", alg, i, placements[i].Bin)
+			}
+		}
+		if placements[3].Bin != -1 {
+			t.Errorf("algorithm %d: oversized item should not be placed, got %v", alg, placements[3])
+		}
+		if !slices.Equal(occupancy, []float64{1}) {
+			t.Errorf("algorithm %d: occupancy mismatch, got %v", alg, occupancy)
+		}
+	}
+}
+
+func TestPacker_Rotate(t *testing.T) {
+	p := loc.Packer[int]{Bin: loc.Xyxy(0, 0, 100, 20), AllowRotate: true}
+	placements, _ := p.Pack([]loc.Point[int]{loc.Xy(20, 100)})
+	want := loc.Placement[int]{Bin: 0, Rect: loc.Xyxy(0, 0, 100, 20), Rotated: true}
+	if placements[0] != want {
+		t.Errorf("Pack rotate mismatch, want %v, got %v", want, placements[0])
+	}
+}
+
+func TestPacker_EmptyBin(t *testing.T) {
+	p := loc.Packer[int]{Bin: loc.Xyxy(0, 0, 0, 10)}
+	placements, occupancy := p.Pack([]loc.Point[int]{loc.Xy(0, 5), loc.Xy(3, 3)})
+	if placements[1].Bin != -1 {
+		t.Errorf("item should not fit an empty bin, got %v", placements[1])
+	}
+	for b, o := range occupancy {
+		if o != 0 {
+			t.Errorf("bin %d occupancy should be 0, got %v", b, o)
+		}
+	}
+}
+
+func TestPacker_ZeroSize(t *testing.T) {
+	sizes := []loc.Point[int]{loc.Xy(0, 8), loc.Xy(0, 16), loc.Xy(8, 17), loc.Xy(12, 8), loc.Xy(10, 15), loc.Xy(15, 0), loc.Xy(0, 0)}
+	var nonzero []loc.Point[int]
+	for _, s := range sizes {
+		if s.X != 0 && s.Y != 0 {
+			nonzero = append(nonzero, s)
+		}
+	}
+	for _, algo := range []loc.PackAlgorithm{loc.PackMaxRects, loc.PackSkyline, loc.PackGuillotine} {
+		p := loc.Packer[int]{Algorithm: algo, Bin: loc.Xyxy(0, 0, 32, 32)}
+		placements, _ := p.Pack(sizes)
+		checkPacking(t, "zero size", p, sizes, placements)
+		for i, pl := range placements {
+			if pl.Bin != 0 {
+				t.Errorf("algorithm %d: item %d %v should be placed in bin 0, got %v", algo, i, sizes[i], pl)
+			}
+		}
+		// Zero-size items must not change where the others go.
+		want, _ := p.Pack(nonzero)
+		var got []loc.Placement[int]
+		for i, pl := range placements {
+			if sizes[i].X != 0 && sizes[i].Y != 0 {
+				got = append(got, pl)
+			}
+		}
+		if !slices.Equal(want, got) {
+			t.Errorf("algorithm %d: zero-size items moved the others, want %v, got %v", algo, want, got)
+		}
+	}
+}
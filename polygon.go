@@ -0,0 +1,225 @@
+package loc
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// Polygon is a closed polygon given by its vertices in order. The last vertex
+// connects back to the first; it need not be repeated.
+type Polygon[S ng.Scalar] []Point[S]
+
+// Orientation is the direction in which the vertices of a polygon run, as seen
+// on screen with Y increasing downward.
+type Orientation int
+
+const (
+	Degenerate       Orientation = iota // zero area
+	Clockwise                           // positive signed area
+	CounterClockwise                    // negative signed area
+)
+
+// FillRule decides which points are inside a self-intersecting or nested
+// polygon.
+type FillRule int
+
+const (
+	EvenOdd FillRule = iota // inside if a ray crosses an odd number of edges
+	NonZero                 // inside if the winding number is not zero
+)
+
+// Polygon returns r as the 4-vertex polygon Min, top-right, Max, bottom-left,
+// which runs clockwise on screen.
+func (r Rect[S]) Polygon() Polygon[S] {
+	return Polygon[S]{
+		r.Min,
+		{X: r.Max.X, Y: r.Min.Y},
+		r.Max,
+		{X: r.Min.X, Y: r.Max.Y},
+	}
+}
+
+// Edges returns the edges of p in order, ending with the closing edge.
+func (p Polygon[S]) Edges() iter.Seq[Segment[S]] {
+	return func(yield func(Segment[S]) bool) {
+		for i, a := range p {
+			if !yield(Segment[S]{A: a, B: p[(i+1)%len(p)]}) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds returns the smallest rectangle whose closure contains p.
+func (p Polygon[S]) Bounds() Rect[S] {
+	if len(p) == 0 {
+		return Rect[S]{}
+	}
+	b := Rect[S]{Min: p[0], Max: p[0]}
+	for _, v := range p[1:] {
+		b.Min.X, b.Min.Y = min(b.Min.X, v.X), min(b.Min.Y, v.Y)
+		b.Max.X, b.Max.Y = max(b.Max.X, v.X), max(b.Max.Y, v.Y)
+	}
+	return b
+}
+
+// SignedArea returns the area of p, positive if its vertices run clockwise on
+// screen and negative if they run counterclockwise. In Y-up coordinates the
+// signs are swapped.
+func (p Polygon[S]) SignedArea() float64 {
+	var sum float64
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		sum += float64(a.X)*float64(b.Y) - float64(b.X)*float64(a.Y)
+	}
+	return sum / 2
+}
+
+// Area returns the absolute area of p.
+func (p Polygon[S]) Area() float64 {
+	return math.Abs(p.SignedArea())
+}
+
+// Orientation returns the direction in which the vertices of p run.
+func (p Polygon[S]) Orientation() Orientation {
+	switch a := p.SignedArea(); {
+	case a > 0:
+		return Clockwise
+	case a < 0:
+		return CounterClockwise
+	}
+	return Degenerate
+}
+
+// Reverse returns p with its vertices in reverse order.
+func (p Polygon[S]) Reverse() Polygon[S] {
+	q := make(Polygon[S], len(p))
+	for i, v := range p {
+		q[len(p)-1-i] = v
+	}
+	return q
+}
+
+// Contains reports whether pt is inside p under the given fill rule. Edges are
+// half-open like Point.In: a point on a left or top edge is inside and one on
+// a right or bottom edge is not, so that polygons sharing an edge never both
+// contain a point on it, and r.Polygon().Contains(pt, rule) == pt.In(r).
+func (p Polygon[S]) Contains(pt Point[S], rule FillRule) bool {
+	x, y := float64(pt.X), float64(pt.Y)
+	winding := 0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		ay, by := float64(a.Y), float64(b.Y)
+		if (ay <= y) == (by <= y) {
+			continue
+		}
+		ax, bx := float64(a.X), float64(b.X)
+		if x >= ax+(y-ay)*(bx-ax)/(by-ay) {
+			continue
+		}
+		if ay <= y {
+			winding++
+		} else {
+			winding--
+		}
+	}
+	if rule == NonZero {
+		return winding != 0
+	}
+	return winding%2 != 0
+}
+
+// Convex reports whether p is a convex polygon. Collinear and repeated
+// vertices are allowed; self-intersecting polygons are not convex.
+func (p Polygon[S]) Convex() bool {
+	if len(p) < 3 {
+		return false
+	}
+	var sign float64
+	var dirs []bool // signs of the non-vertical edges' dx, in order
+	for i := range p {
+		a, b, c := p[i].Float64(), p[(i+1)%len(p)].Float64(), p[(i+2)%len(p)].Float64()
+		e := b.Sub(a)
+		if z := cross(e, c.Sub(b)); z != 0 {
+			if sign != 0 && (z > 0) != (sign > 0) {
+				return false
+			}
+			sign = z
+		}
+		if e.X != 0 {
+			dirs = append(dirs, e.X > 0)
+		}
+	}
+	// Turning the same way at every vertex still allows a star that winds
+	// around more than once; a simple convex polygon changes horizontal
+	// direction exactly twice.
+	flips := 0
+	for i, d := range dirs {
+		if d != dirs[(i+1)%len(dirs)] {
+			flips++
+		}
+	}
+	return sign != 0 && flips <= 2
+}
+
+// ClipRect returns the part of p inside the closure of r, the closed box
+// [Min, Max], computed with the Sutherland–Hodgman algorithm. Integer
+// rectangles are clipped the same way, as by Segment.ClipRect, so that
+// r.Polygon().ClipRect(r) is r.Polygon() itself. The result may contain
+// degenerate edges along the border of r when p is concave, but no repeated
+// vertices. Intersection points are rounded to the nearest integer for
+// integer types.
+func (p Polygon[S]) ClipRect(r Rect[S]) Polygon[S] {
+	if r.Empty() {
+		return nil
+	}
+	out := p
+	edges := [4]struct {
+		inside func(Point[S]) bool
+		cut    func(a, b Point[S]) Point[S]
+	}{
+		{func(v Point[S]) bool { return v.X >= r.Min.X }, func(a, b Point[S]) Point[S] { return cutX(a, b, r.Min.X) }},
+		{func(v Point[S]) bool { return v.X <= r.Max.X }, func(a, b Point[S]) Point[S] { return cutX(a, b, r.Max.X) }},
+		{func(v Point[S]) bool { return v.Y >= r.Min.Y }, func(a, b Point[S]) Point[S] { return cutY(a, b, r.Min.Y) }},
+		{func(v Point[S]) bool { return v.Y <= r.Max.Y }, func(a, b Point[S]) Point[S] { return cutY(a, b, r.Max.Y) }},
+	}
+	for _, e := range edges {
+		in := out
+		out = nil
+		for i, b := range in {
+			a := in[(i+len(in)-1)%len(in)]
+			switch ain, bin := e.inside(a), e.inside(b); {
+			case ain && bin:
+				out = append(out, b)
+			case ain:
+				out = append(out, e.cut(a, b))
+			case bin:
+				out = append(out, e.cut(a, b), b)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+	}
+	// Vertices on the border of r are emitted again as intersections.
+	out = slices.Compact(out)
+	if len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// cutX returns the point of the segment ab at x.
+func cutX[S ng.Scalar](a, b Point[S], x S) Point[S] {
+	t := (float64(x) - float64(a.X)) / (float64(b.X) - float64(a.X))
+	return Point[S]{X: x, Y: roundS[S](float64(a.Y) + t*(float64(b.Y)-float64(a.Y)))}
+}
+
+// cutY returns the point of the segment ab at y.
+func cutY[S ng.Scalar](a, b Point[S], y S) Point[S] {
+	t := (float64(y) - float64(a.Y)) / (float64(b.Y) - float64(a.Y))
+	return Point[S]{X: roundS[S](float64(a.X) + t*(float64(b.X)-float64(a.X))), Y: y}
+}
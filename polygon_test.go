@@ -0,0 +1,108 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestPolygon_Area(t *testing.T) {
+	p := loc.Xywh(0, 0, 4, 3).Polygon()
+	if got := p.SignedArea(); got != 12 {
+		t.Errorf("SignedArea mismatch, got %v", got)
+	}
+	if got := p.Orientation(); got != loc.Clockwise {
+		t.Errorf("Orientation mismatch, got %v", got)
+	}
+	if got := p.Reverse().SignedArea(); got != -12 {
+		t.Errorf("reversed SignedArea mismatch, got %v", got)
+	}
+	if got := p.Reverse().Orientation(); got != loc.CounterClockwise {
+		t.Errorf("reversed Orientation mismatch, got %v", got)
+	}
+	if got := (loc.Polygon[int]{{0, 0}, {1, 1}, {2, 2}}).Orientation(); got != loc.Degenerate {
+		t.Errorf("collinear Orientation mismatch, got %v", got)
+	}
+	diamond := loc.Polygon[int]{{2, 0}, {4, 2}, {2, 4}, {0, 2}}
+	if got := diamond.Bounds(); got != loc.Xyxy(0, 0, 4, 4) {
+		t.Errorf("Bounds mismatch, got %v", got)
+	}
+	if got := diamond.Area(); got != 8 {
+		t.Errorf("Area mismatch, got %v", got)
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	// A rectangle's polygon agrees with Point.In, including on its edges.
+	r := loc.Xyxy(0, 0, 4, 3)
+	p := r.Polygon()
+	for y := -1; y <= 4; y++ {
+		for x := -1; x <= 5; x++ {
+			pt := loc.Xy(x, y)
+			for _, rule := range []loc.FillRule{loc.EvenOdd, loc.NonZero} {
+				if got, want := p.Contains(pt, rule), pt.In(r); got != want {
+					t.Errorf("Contains(%v, %d) = %v, want %v", pt, rule, got, want)
+				}
+			}
+		}
+	}
+
+	// A pentagram: the center has winding number 2.
+	star := loc.Polygon[float64]{{50, 0}, {79, 90}, {2, 35}, {98, 35}, {21, 90}}
+	center := loc.Xy(50.0, 50.0)
+	if star.Contains(center, loc.EvenOdd) {
+		t.Errorf("even-odd star should not contain its center")
+	}
+	if !star.Contains(center, loc.NonZero) {
+		t.Errorf("nonzero star should contain its center")
+	}
+	if !star.Contains(loc.Xy(50.0, 10.0), loc.EvenOdd) {
+		t.Errorf("star should contain a point in its tip")
+	}
+}
+
+func TestPolygon_Convex(t *testing.T) {
+	tests := []struct {
+		name string
+		p    loc.Polygon[int]
+		want bool
+	}{
+		{"rect", loc.Xywh(0, 0, 4, 3).Polygon(), true},
+		{"reversed", loc.Xywh(0, 0, 4, 3).Polygon().Reverse(), true},
+		{"triangle with collinear vertex", loc.Polygon[int]{{0, 0}, {2, 0}, {4, 0}, {2, 3}}, true},
+		{"concave", loc.Polygon[int]{{0, 0}, {4, 0}, {2, 1}, {4, 4}, {0, 4}}, false},
+		{"star", loc.Polygon[int]{{50, 0}, {79, 90}, {2, 35}, {98, 35}, {21, 90}}, false},
+		{"line", loc.Polygon[int]{{0, 0}, {1, 1}, {2, 2}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Convex(); got != tt.want {
+			t.Errorf("%s: Convex = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPolygon_ClipRect(t *testing.T) {
+	diamond := loc.Polygon[int]{{5, 0}, {10, 5}, {5, 10}, {0, 5}}
+	got := diamond.ClipRect(loc.Xyxy(0, 0, 5, 10))
+	want := loc.Polygon[int]{{5, 0}, {5, 10}, {0, 5}}
+	if !slices.Equal(got, want) {
+		t.Errorf("ClipRect mismatch, want %v, got %v", want, got)
+	}
+	if r := loc.Xyxy(2, 3, 7, 9); !slices.Equal(r.Polygon().ClipRect(r), r.Polygon()) {
+		t.Errorf("a rect's polygon clipped to the rect should be unchanged, got %v", r.Polygon().ClipRect(r))
+	}
+	if got := diamond.ClipRect(loc.Xyxy(20, 20, 30, 30)); got != nil {
+		t.Errorf("disjoint ClipRect should be empty, got %v", got)
+	}
+	if got := diamond.ClipRect(loc.Xyxy(-5, -5, 15, 15)); !slices.Equal(got, diamond) {
+		t.Errorf("enclosing ClipRect should be unchanged, got %v", got)
+	}
+	tri := loc.Polygon[float64]{{0, 0}, {10, 0}, {0, 10}}
+	if got := tri.ClipRect(loc.Xyxy(0.0, 0.0, 4.0, 4.0)).Area(); got != 16 {
+		t.Errorf("clipped area mismatch, got %v", got)
+	}
+	if got := tri.ClipRect(loc.Xyxy(0.0, 0.0, 8.0, 8.0)).Area(); got != 46 {
+		t.Errorf("clipped area mismatch, got %v", got)
+	}
+}
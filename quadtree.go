@@ -0,0 +1,264 @@
+package loc
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// An Entry is a value stored in a spatial index with its bounds.
+type Entry[S ng.Scalar, V any] struct {
+	Bounds Rect[S]
+	Value  V
+}
+
+// A Quadtree is a spatial index of values keyed by rectangles.
+//
+// Each value is stored at most once; inserting a value again moves it.
+// Entries outside the bounds of the tree are kept at the root and are still
+// found by queries, only less efficiently.
+type Quadtree[S ng.Scalar, V comparable] struct {
+	root     *qtNode[S, V]
+	nodes    map[V]*qtNode[S, V]
+	maxItems int
+	maxDepth int
+}
+
+type qtNode[S ng.Scalar, V comparable] struct {
+	bounds   Rect[S]
+	depth    int
+	items    []Entry[S, V]
+	children []*qtNode[S, V]
+}
+
+// NewQuadtree returns an empty quadtree covering bounds. A node is split when
+// it holds more than maxItems entries, unless it is maxDepth levels deep.
+func NewQuadtree[S ng.Scalar, V comparable](bounds Rect[S], maxItems, maxDepth int) *Quadtree[S, V] {
+	return &Quadtree[S, V]{
+		root:     &qtNode[S, V]{bounds: bounds},
+		nodes:    map[V]*qtNode[S, V]{},
+		maxItems: max(maxItems, 1),
+		maxDepth: maxDepth,
+	}
+}
+
+// Bounds returns the bounds the tree was created with.
+func (t *Quadtree[S, V]) Bounds() Rect[S] {
+	return t.root.bounds
+}
+
+// Len returns the number of values in the tree.
+func (t *Quadtree[S, V]) Len() int {
+	return len(t.nodes)
+}
+
+// Insert adds v with bounds r, or moves v to r if it is already present.
+func (t *Quadtree[S, V]) Insert(r Rect[S], v V) {
+	if _, ok := t.nodes[v]; ok {
+		t.Update(v, r)
+		return
+	}
+	t.insert(t.root, Entry[S, V]{Bounds: r, Value: v})
+}
+
+// Remove removes v and reports whether it was present.
+func (t *Quadtree[S, V]) Remove(v V) bool {
+	n, ok := t.nodes[v]
+	if !ok {
+		return false
+	}
+	delete(t.nodes, v)
+	n.items = slices.DeleteFunc(n.items, func(e Entry[S, V]) bool { return e.Value == v })
+	return true
+}
+
+// Update moves v to r and reports whether v was present.
+func (t *Quadtree[S, V]) Update(v V, r Rect[S]) bool {
+	n, ok := t.nodes[v]
+	if !ok {
+		return false
+	}
+	if (n == t.root || fits(r, n.bounds)) && n.child(r) == nil {
+		for i := range n.items {
+			if n.items[i].Value == v {
+				n.items[i].Bounds = r
+			}
+		}
+		return true
+	}
+	t.Remove(v)
+	t.insert(t.root, Entry[S, V]{Bounds: r, Value: v})
+	return true
+}
+
+// Get returns the bounds of v and reports whether v is present.
+func (t *Quadtree[S, V]) Get(v V) (Rect[S], bool) {
+	n, ok := t.nodes[v]
+	if !ok {
+		return Rect[S]{}, false
+	}
+	for _, e := range n.items {
+		if e.Value == v {
+			return e.Bounds, true
+		}
+	}
+	return Rect[S]{}, false
+}
+
+// Query returns a sequence of the entries whose bounds overlap r.
+func (t *Quadtree[S, V]) Query(r Rect[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		t.root.walk(yield, func(b Rect[S]) bool { return b.Overlaps(r) })
+	}
+}
+
+// QueryPoint returns a sequence of the entries whose bounds contain p.
+func (t *Quadtree[S, V]) QueryPoint(p Point[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		t.root.walk(yield, p.In)
+	}
+}
+
+// All returns a sequence of all entries.
+func (t *Quadtree[S, V]) All() iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		t.root.walk(yield, func(Rect[S]) bool { return true })
+	}
+}
+
+// Nearest returns a sequence of all entries in increasing Euclidean distance
+// from p to their bounds. Stop after k entries for a k-nearest search.
+func (t *Quadtree[S, V]) Nearest(p Point[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		var q nearQueue[qtNode[S, V], S, V]
+		q.push(0, t.root, Entry[S, V]{})
+		for q.Len() > 0 {
+			it := q.pop()
+			if it.node == nil {
+				if !yield(it.entry) {
+					return
+				}
+				continue
+			}
+			for _, e := range it.node.items {
+				q.push(distSq(p, e.Bounds), nil, e)
+			}
+			for _, c := range it.node.children {
+				q.push(distSq(p, c.bounds), c, Entry[S, V]{})
+			}
+		}
+	}
+}
+
+func (t *Quadtree[S, V]) insert(n *qtNode[S, V], e Entry[S, V]) {
+	for {
+		c := n.child(e.Bounds)
+		if c == nil {
+			break
+		}
+		n = c
+	}
+	n.items = append(n.items, e)
+	t.nodes[e.Value] = n
+	if n.children == nil && len(n.items) > t.maxItems && n.depth < t.maxDepth {
+		t.split(n)
+	}
+}
+
+// split divides n into quadrants and pushes down the entries that fit.
+func (t *Quadtree[S, V]) split(n *qtNode[S, V]) {
+	b := n.bounds
+	c := b.Center()
+	if c.X == b.Min.X || c.Y == b.Min.Y {
+		return // too small to divide
+	}
+	n.children = []*qtNode[S, V]{
+		{bounds: Xyxy(b.Min.X, b.Min.Y, c.X, c.Y), depth: n.depth + 1},
+		{bounds: Xyxy(c.X, b.Min.Y, b.Max.X, c.Y), depth: n.depth + 1},
+		{bounds: Xyxy(b.Min.X, c.Y, c.X, b.Max.Y), depth: n.depth + 1},
+		{bounds: Xyxy(c.X, c.Y, b.Max.X, b.Max.Y), depth: n.depth + 1},
+	}
+	items := n.items
+	n.items = nil
+	for _, e := range items {
+		if ch := n.child(e.Bounds); ch != nil {
+			t.insert(ch, e)
+		} else {
+			n.items = append(n.items, e)
+		}
+	}
+}
+
+// child returns the child of n that entirely contains r, if any.
+func (n *qtNode[S, V]) child(r Rect[S]) *qtNode[S, V] {
+	for _, c := range n.children {
+		if fits(r, c.bounds) {
+			return c
+		}
+	}
+	return nil
+}
+
+// walk yields the entries of n and its descendants whose bounds match, only
+// descending into children whose bounds match.
+func (n *qtNode[S, V]) walk(yield func(Entry[S, V]) bool, match func(Rect[S]) bool) bool {
+	for _, e := range n.items {
+		if match(e.Bounds) && !yield(e) {
+			return false
+		}
+	}
+	for _, c := range n.children {
+		if match(c.bounds) && !c.walk(yield, match) {
+			return false
+		}
+	}
+	return true
+}
+
+// fits reports whether r lies within b. Unlike Rect.In, an empty r only fits
+// if its coordinates lie within b.
+func fits[S ng.Scalar](r, b Rect[S]) bool {
+	return b.Min.X <= r.Min.X && r.Max.X <= b.Max.X &&
+		b.Min.Y <= r.Min.Y && r.Max.Y <= b.Max.Y
+}
+
+type nearItem[N any, S ng.Scalar, V any] struct {
+	dist  float64
+	seq   int
+	node  *N
+	entry Entry[S, V]
+}
+
+// nearQueue is a priority queue of nodes and entries ordered by distance,
+// then by push order. It drives best-first nearest neighbour searches.
+type nearQueue[N any, S ng.Scalar, V any] struct {
+	items []nearItem[N, S, V]
+	seq   int
+}
+
+func (q *nearQueue[N, S, V]) push(dist float64, n *N, e Entry[S, V]) {
+	q.seq++
+	heap.Push(q, nearItem[N, S, V]{dist: dist, seq: q.seq, node: n, entry: e})
+}
+
+func (q *nearQueue[N, S, V]) pop() nearItem[N, S, V] {
+	return heap.Pop(q).(nearItem[N, S, V])
+}
+
+func (q *nearQueue[N, S, V]) Len() int { return len(q.items) }
+func (q *nearQueue[N, S, V]) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if a.dist != b.dist {
+		return a.dist < b.dist
+	}
+	return a.seq < b.seq
+}
+func (q *nearQueue[N, S, V]) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *nearQueue[N, S, V]) Push(x any)    { q.items = append(q.items, x.(nearItem[N, S, V])) }
+func (q *nearQueue[N, S, V]) Pop() any {
+	it := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return it
+}
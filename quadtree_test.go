@@ -0,0 +1,121 @@
+package loc_test
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+	"github.com/eihigh/ng"
+)
+
+func randomRects(n int, seed uint64) []loc.Rect[int] {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	rects := make([]loc.Rect[int], n)
+	for i := range rects {
+		rects[i] = loc.Xywh(rng.IntN(1000), rng.IntN(1000), rng.IntN(40), rng.IntN(40))
+	}
+	return rects
+}
+
+func values[S ng.Scalar, V any](seq iter.Seq[loc.Entry[S, V]]) []V {
+	var vs []V
+	for e := range seq {
+		vs = append(vs, e.Value)
+	}
+	return vs
+}
+
+func TestQuadtree_Query(t *testing.T) {
+	rects := randomRects(500, 1)
+	qt := loc.NewQuadtree[int, int](loc.Xyxy(0, 0, 1024, 1024), 8, 8)
+	for i, r := range rects {
+		qt.Insert(r, i)
+	}
+	for _, q := range randomRects(50, 2) {
+		q = q.Inset(-50)
+		var want []int
+		for i, r := range rects {
+			if r.Overlaps(q) {
+				want = append(want, i)
+			}
+		}
+		got := values(qt.Query(q))
+		slices.Sort(got)
+		if !slices.Equal(want, got) {
+			t.Errorf("Query(%v) mismatch, want %v, got %v", q, want, got)
+		}
+	}
+
+	p := loc.Xy(500, 500)
+	var want []int
+	for i, r := range rects {
+		if p.In(r) {
+			want = append(want, i)
+		}
+	}
+	got := values(qt.QueryPoint(p))
+	slices.Sort(got)
+	if !slices.Equal(want, got) {
+		t.Errorf("QueryPoint(%v) mismatch, want %v, got %v", p, want, got)
+	}
+}
+
+func TestQuadtree_RemoveUpdate(t *testing.T) {
+	qt := loc.NewQuadtree[int, string](loc.Xyxy(0, 0, 100, 100), 1, 4)
+	qt.Insert(loc.Xywh(10, 10, 5, 5), "a")
+	qt.Insert(loc.Xywh(80, 80, 5, 5), "b")
+	qt.Insert(loc.Xywh(200, 200, 5, 5), "outside")
+
+	if got := values(qt.QueryPoint(loc.Xy(201, 201))); !slices.Equal(got, []string{"outside"}) {
+		t.Errorf("QueryPoint outside mismatch, got %v", got)
+	}
+	if !qt.Update("a", loc.Xywh(60, 10, 5, 5)) {
+		t.Errorf("Update(a) should succeed")
+	}
+	if got := values(qt.Query(loc.Xyxy(0, 0, 50, 50))); len(got) != 0 {
+		t.Errorf("Query after Update should be empty, got %v", got)
+	}
+	if got := values(qt.Query(loc.Xyxy(50, 0, 100, 50))); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Query after Update mismatch, got %v", got)
+	}
+	if !qt.Remove("b") || qt.Remove("b") {
+		t.Errorf("Remove(b) should succeed once")
+	}
+	if qt.Len() != 2 {
+		t.Errorf("Len mismatch, want 2, got %d", qt.Len())
+	}
+	if r, ok := qt.Get("a"); !ok || r != loc.Xywh(60, 10, 5, 5) {
+		t.Errorf("Get(a) mismatch, got %v, %v", r, ok)
+	}
+}
+
+func TestQuadtree_Nearest(t *testing.T) {
+	rects := randomRects(300, 3)
+	qt := loc.NewQuadtree[int, int](loc.Xyxy(0, 0, 1024, 1024), 4, 8)
+	for i, r := range rects {
+		qt.Insert(r, i)
+	}
+	p := loc.Xy(300, 700)
+	dist := func(r loc.Rect[int]) int {
+		dx := max(r.Min.X-p.X, 0, p.X-r.Max.X)
+		dy := max(r.Min.Y-p.Y, 0, p.Y-r.Max.Y)
+		return dx*dx + dy*dy
+	}
+	var got []int
+	for e := range qt.Nearest(p) {
+		got = append(got, dist(e.Bounds))
+		if len(got) == 10 {
+			break
+		}
+	}
+	want := make([]int, len(rects))
+	for i, r := range rects {
+		want[i] = dist(r)
+	}
+	slices.Sort(want)
+	if !slices.Equal(want[:10], got) {
+		t.Errorf("Nearest distances mismatch, want %v, got %v", want[:10], got)
+	}
+}
@@ -0,0 +1,215 @@
+package loc
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/eihigh/ng"
+)
+
+// A Region is a set of points represented by non-overlapping rectangles.
+//
+// The rectangles are kept in a canonical form: they are divided into
+// horizontal bands sorted top to bottom, the rectangles of a band are sorted
+// left to right, and vertically adjacent bands with the same horizontal
+// extents are merged. Two regions containing the same points therefore have
+// the same rectangles. The zero Region is empty.
+type Region[S ng.Scalar] struct {
+	rects []Rect[S]
+}
+
+// NewRegion returns the region covering the union of rects.
+func NewRegion[S ng.Scalar](rects ...Rect[S]) Region[S] {
+	return combine(rects, nil, func(a, b bool) bool { return a })
+}
+
+// String returns a string representation of g like "{(0,0)-(2,1) (3,0)-(4,1)}".
+func (g Region[S]) String() string {
+	parts := make([]string, len(g.rects))
+	for i, r := range g.rects {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// Union returns the points in g or h.
+func (g Region[S]) Union(h Region[S]) Region[S] {
+	return combine(g.rects, h.rects, func(a, b bool) bool { return a || b })
+}
+
+// Intersect returns the points in both g and h.
+func (g Region[S]) Intersect(h Region[S]) Region[S] {
+	return combine(g.rects, h.rects, func(a, b bool) bool { return a && b })
+}
+
+// Subtract returns the points in g but not in h.
+func (g Region[S]) Subtract(h Region[S]) Region[S] {
+	return combine(g.rects, h.rects, func(a, b bool) bool { return a && !b })
+}
+
+// Xor returns the points in exactly one of g and h.
+func (g Region[S]) Xor(h Region[S]) Region[S] {
+	return combine(g.rects, h.rects, func(a, b bool) bool { return a != b })
+}
+
+// Add returns the region g translated by p.
+func (g Region[S]) Add(p Point[S]) Region[S] {
+	rects := make([]Rect[S], len(g.rects))
+	for i, r := range g.rects {
+		rects[i] = r.Add(p)
+	}
+	return Region[S]{rects: rects}
+}
+
+// Empty reports whether the region contains no points.
+func (g Region[S]) Empty() bool {
+	return len(g.rects) == 0
+}
+
+// Eq reports whether g and h contain the same set of points.
+func (g Region[S]) Eq(h Region[S]) bool {
+	return slices.Equal(g.rects, h.rects)
+}
+
+// Len returns the number of rectangles in g.
+func (g Region[S]) Len() int {
+	return len(g.rects)
+}
+
+// Rects returns a sequence of the rectangles in g in canonical order.
+func (g Region[S]) Rects() iter.Seq[Rect[S]] {
+	return slices.Values(g.rects)
+}
+
+// Area returns the total area of g.
+func (g Region[S]) Area() S {
+	var a S
+	for _, r := range g.rects {
+		a += r.Dx() * r.Dy()
+	}
+	return a
+}
+
+// Bounds returns the smallest rectangle containing g.
+func (g Region[S]) Bounds() Rect[S] {
+	var b Rect[S]
+	for _, r := range g.rects {
+		b = b.Union(r)
+	}
+	return b
+}
+
+// Contains reports whether p is in g.
+func (g Region[S]) Contains(p Point[S]) bool {
+	for _, r := range g.rects {
+		if r.Min.Y > p.Y {
+			break
+		}
+		if p.In(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps reports whether g and r have a non-empty intersection.
+func (g Region[S]) Overlaps(r Rect[S]) bool {
+	for _, s := range g.rects {
+		if s.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+type regionEdge[S ng.Scalar] struct {
+	x      S
+	da, db int
+}
+
+// combine returns the canonical region of the points p for which
+// op(p in any of a, p in any of b) holds.
+func combine[S ng.Scalar](a, b []Rect[S], op func(inA, inB bool) bool) Region[S] {
+	var ys []S
+	for _, rs := range [][]Rect[S]{a, b} {
+		for _, r := range rs {
+			if !r.Empty() {
+				ys = append(ys, r.Min.Y, r.Max.Y)
+			}
+		}
+	}
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+
+	var out []Rect[S]
+	var edges []regionEdge[S]
+	var spans, prev []Rect[S] // spans of the current and previous band, as X ranges
+	prevStart := 0
+	for i := 0; i+1 < len(ys); i++ {
+		y0, y1 := ys[i], ys[i+1]
+		edges = edges[:0]
+		for _, r := range a {
+			if !r.Empty() && r.Min.Y <= y0 && y1 <= r.Max.Y {
+				edges = append(edges, regionEdge[S]{r.Min.X, 1, 0}, regionEdge[S]{r.Max.X, -1, 0})
+			}
+		}
+		for _, r := range b {
+			if !r.Empty() && r.Min.Y <= y0 && y1 <= r.Max.Y {
+				edges = append(edges, regionEdge[S]{r.Min.X, 0, 1}, regionEdge[S]{r.Max.X, 0, -1})
+			}
+		}
+		slices.SortFunc(edges, func(p, q regionEdge[S]) int {
+			switch {
+			case p.x < q.x:
+				return -1
+			case p.x > q.x:
+				return 1
+			}
+			return 0
+		})
+
+		spans = spans[:0]
+		ca, cb := 0, 0
+		for j := 0; j < len(edges); {
+			x := edges[j].x
+			for ; j < len(edges) && edges[j].x == x; j++ {
+				ca += edges[j].da
+				cb += edges[j].db
+			}
+			if j == len(edges) || !op(ca > 0, cb > 0) {
+				continue
+			}
+			next := edges[j].x
+			if n := len(spans); n > 0 && spans[n-1].Max.X == x {
+				spans[n-1].Max.X = next
+			} else {
+				spans = append(spans, Xyxy(x, y0, next, y1))
+			}
+		}
+
+		// Merge with the band above when the X ranges are identical.
+		if len(spans) > 0 && len(spans) == len(prev) && prev[0].Max.Y == y0 && sameSpans(spans, prev) {
+			for k := prevStart; k < len(out); k++ {
+				out[k].Max.Y = y1
+			}
+			for k := range prev {
+				prev[k].Max.Y = y1
+			}
+			continue
+		}
+		prevStart = len(out)
+		out = append(out, spans...)
+		prev = append(prev[:0], spans...)
+	}
+	return Region[S]{rects: out}
+}
+
+func sameSpans[S ng.Scalar](a, b []Rect[S]) bool {
+	for i := range a {
+		if a[i].Min.X != b[i].Min.X || a[i].Max.X != b[i].Max.X {
+			return false
+		}
+	}
+	return true
+}
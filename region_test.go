@@ -0,0 +1,82 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestRegion_Subtract(t *testing.T) {
+	screen := loc.NewRegion(loc.Xyxy(0, 0, 100, 100))
+	popup := loc.NewRegion(loc.Xyxy(20, 30, 60, 70))
+	got := screen.Subtract(popup)
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 100, 30),
+		loc.Xyxy(0, 30, 20, 70),
+		loc.Xyxy(60, 30, 100, 70),
+		loc.Xyxy(0, 70, 100, 100),
+	}
+	if !slices.Equal(want, slices.Collect(got.Rects())) {
+		t.Errorf("Subtract mismatch, want %v, got %v", want, got)
+	}
+	if want := 100*100 - 40*40; got.Area() != want {
+		t.Errorf("Subtract area mismatch, want %d, got %d", want, got.Area())
+	}
+	if got.Contains(loc.Xy(30, 40)) {
+		t.Errorf("Subtract should not contain (30,40)")
+	}
+	if !got.Contains(loc.Xy(60, 40)) {
+		t.Errorf("Subtract should contain (60,40)")
+	}
+}
+
+func TestRegion_UnionCanonical(t *testing.T) {
+	// The same L shape built from different pieces.
+	g := loc.NewRegion(loc.Xyxy(0, 0, 10, 20), loc.Xyxy(10, 10, 20, 20))
+	h := loc.NewRegion(loc.Xyxy(0, 0, 10, 10)).Union(loc.NewRegion(loc.Xyxy(0, 10, 20, 20)))
+	if !g.Eq(h) {
+		t.Errorf("Union should be canonical, got %v and %v", g, h)
+	}
+	if want := loc.Xyxy(0, 0, 20, 20); g.Bounds() != want {
+		t.Errorf("Bounds mismatch, want %v, got %v", want, g.Bounds())
+	}
+	if want := 300; g.Area() != want {
+		t.Errorf("Area mismatch, want %d, got %d", want, g.Area())
+	}
+}
+
+func TestRegion_Intersect(t *testing.T) {
+	g := loc.NewRegion(loc.Xyxy(0, 0, 10, 10), loc.Xyxy(20, 0, 30, 10))
+	h := loc.NewRegion(loc.Xyxy(5, 5, 25, 15))
+	got := g.Intersect(h)
+	want := loc.NewRegion(loc.Xyxy(5, 5, 10, 10), loc.Xyxy(20, 5, 25, 10))
+	if !want.Eq(got) {
+		t.Errorf("Intersect mismatch, want %v, got %v", want, got)
+	}
+	if g.Intersect(loc.Region[int]{}).Len() != 0 {
+		t.Errorf("Intersect with empty region should be empty")
+	}
+}
+
+func TestRegion_Xor(t *testing.T) {
+	g := loc.NewRegion(loc.Xyxy(0, 0, 10, 10))
+	h := loc.NewRegion(loc.Xyxy(5, 0, 15, 10))
+	got := g.Xor(h)
+	want := loc.NewRegion(loc.Xyxy(0, 0, 5, 10), loc.Xyxy(10, 0, 15, 10))
+	if !want.Eq(got) {
+		t.Errorf("Xor mismatch, want %v, got %v", want, got)
+	}
+	if !g.Xor(g).Empty() {
+		t.Errorf("Xor with itself should be empty")
+	}
+}
+
+func TestRegion_Float(t *testing.T) {
+	g := loc.NewRegion(loc.Xyxy(0, 0, 1.5, 1))
+	got := g.Subtract(loc.NewRegion(loc.Xyxy(0.5, 0, 1.0, 1)))
+	want := "{(0,0)-(0.5,1) (1,0)-(1.5,1)}"
+	if got.String() != want {
+		t.Errorf("Subtract float mismatch, want %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,32 @@
+package loc
+
+import (
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// Resize scales a logical screen of the given size into window with mode
+// and places it at the relative position (rx, ry) as with Rect.Within.
+// It returns the destination rectangle, the scale factors, and the parts of
+// window left uncovered, which are the letterbox or pillarbox bars.
+// For integer S the destination size is rounded down to stay within the
+// window, except with FitCover where it is rounded up.
+func Resize[S ng.Scalar](size Point[S], window Rect[S], mode FitMode, rx, ry float64) (dst Rect[S], scale Point[float64], bars []Rect[S]) {
+	scale = mode.Scale(size.Float64(), window.Size().Float64())
+	w, h := float64(size.X)*scale.X, float64(size.Y)*scale.Y
+	var dw, dh S
+	if mode == FitCover {
+		dw, dh = ceilS[S](snap(w)), ceilS[S](snap(h))
+	} else {
+		dw, dh = floorS[S](snap(w)), floorS[S](snap(h))
+	}
+	dst = Xywh(0, 0, dw, dh).Within(window, rx, ry)
+	if mode == FitPixelPerfect && !isInt[S]() {
+		origin := Xy(S(math.Floor(float64(dst.Min.X))), S(math.Floor(float64(dst.Min.Y))))
+		dst = dst.Sub(dst.Min).Add(origin)
+	}
+	bars = slices.Collect(NewRegion(window).Subtract(NewRegion(dst)).Rects())
+	return dst, scale, bars
+}
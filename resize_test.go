@@ -0,0 +1,101 @@
+package loc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestResize_Contain(t *testing.T) {
+	dst, scale, bars := loc.Resize(loc.Xy(320, 180), loc.Xyxy(0, 0, 1000, 1000), loc.FitContain, 0.5, 0.5)
+	if want := loc.Xyxy(0, 219, 1000, 781); dst != want {
+		t.Errorf("Resize dst mismatch, want %v, got %v", want, dst)
+	}
+	if scale != loc.Xy(1000.0/320, 1000.0/320) {
+		t.Errorf("Resize scale mismatch, got %v", scale)
+	}
+	want := []loc.Rect[int]{loc.Xyxy(0, 0, 1000, 219), loc.Xyxy(0, 781, 1000, 1000)}
+	if len(bars) != 2 || bars[0] != want[0] || bars[1] != want[1] {
+		t.Errorf("Resize bars mismatch, want %v, got %v", want, bars)
+	}
+}
+
+func TestResize_PixelPerfectFloat(t *testing.T) {
+	dst, scale, _ := loc.Resize(loc.Xy(320.0, 180), loc.Xyxy(0.0, 0, 1001, 701), loc.FitPixelPerfect, 0.5, 0.5)
+	if scale != loc.Xy(3.0, 3.0) {
+		t.Errorf("Resize scale mismatch, want 3, got %v", scale)
+	}
+	if want := loc.Xywh(20.0, 80, 960, 540); dst != want {
+		t.Errorf("Resize dst mismatch, want %v, got %v", want, dst)
+	}
+	_, scale, _ = loc.Resize(loc.Xy(320.0, 180), loc.Xyxy(0.0, 0, 200, 200), loc.FitPixelPerfect, 0.5, 0.5)
+	if scale != loc.Xy(0.5, 0.5) {
+		t.Errorf("Resize downscale mismatch, want 0.5, got %v", scale)
+	}
+}
+
+// TestResize_OddSizes checks the invariants of every fit mode over many window sizes.
+func TestResize_OddSizes(t *testing.T) {
+	size := loc.Xy(320, 180)
+	modes := []loc.FitMode{loc.FitStretch, loc.FitContain, loc.FitCover, loc.FitNone, loc.FitInteger, loc.FitPixelPerfect}
+	for _, mode := range modes {
+		for w := 1; w <= 1400; w += 37 {
+			for h := 1; h <= 1100; h += 41 {
+				window := loc.Xywh(-7, 13, w, h)
+				dst, scale, bars := loc.Resize(size, window, mode, 0.5, 0.5)
+
+				var covered int
+				for _, b := range bars {
+					if !b.In(window) || b.Overlaps(dst) {
+						t.Fatalf("mode %d window %v: bar %v is outside the window or overlaps %v", mode, window, b, dst)
+					}
+					covered += b.Dx() * b.Dy()
+				}
+				visible := dst.Intersect(window)
+				if got := covered + visible.Dx()*visible.Dy(); got != w*h {
+					t.Fatalf("mode %d window %v: bars and dst cover %d, want %d", mode, window, got, w*h)
+				}
+
+				wantW := float64(size.X) * scale.X
+				wantH := float64(size.Y) * scale.Y
+				if math.Abs(float64(dst.Dx())-wantW) >= 1 || math.Abs(float64(dst.Dy())-wantH) >= 1 {
+					t.Fatalf("mode %d window %v: dst %v does not keep the aspect at scale %v", mode, window, dst, scale)
+				}
+
+				switch mode {
+				case loc.FitStretch:
+					if dst != window {
+						t.Fatalf("stretch window %v: dst %v is not the window", window, dst)
+					}
+				case loc.FitNone:
+					if scale != loc.Xy(1.0, 1.0) || dst.Size() != size {
+						t.Fatalf("none window %v: dst %v is scaled by %v", window, dst, scale)
+					}
+				case loc.FitContain:
+					if !dst.In(window) {
+						t.Fatalf("contain window %v: dst %v is outside", window, dst)
+					}
+				case loc.FitCover:
+					if !window.In(dst) || len(bars) != 0 {
+						t.Fatalf("cover window %v: dst %v does not cover, bars %v", window, dst, bars)
+					}
+				case loc.FitInteger:
+					if scale.X != math.Floor(scale.X) || scale.X < 1 {
+						t.Fatalf("integer window %v: scale %v is not a whole number >= 1", window, scale)
+					}
+					if scale.X > 1 && !dst.In(window) {
+						t.Fatalf("integer window %v: dst %v is outside", window, dst)
+					}
+				case loc.FitPixelPerfect:
+					if s := scale.X; s >= 1 && s != math.Floor(s) || s < 1 && 1/s != math.Floor(1/s) {
+						t.Fatalf("pixel perfect window %v: scale %v is not n or 1/n", window, scale)
+					}
+					if !dst.In(window) {
+						t.Fatalf("pixel perfect window %v: dst %v is outside", window, dst)
+					}
+				}
+			}
+		}
+	}
+}
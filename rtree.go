@@ -0,0 +1,205 @@
+package loc
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// An RTree is a static spatial index of values keyed by rectangles, built
+// once with Sort-Tile-Recursive bulk loading. It suits scenes such as tile
+// maps that are loaded once and queried often.
+type RTree[S ng.Scalar, V any] struct {
+	root *rtNode[S, V]
+	n    int
+}
+
+type rtNode[S ng.Scalar, V any] struct {
+	bounds   Rect[S]
+	children []*rtNode[S, V]
+	entries  []Entry[S, V]
+	hasEmpty bool // whether any entry below has empty bounds
+}
+
+// NewRTree returns an R-tree holding entries, with at most nodeSize children
+// per node. A nodeSize less than 2 selects a default.
+func NewRTree[S ng.Scalar, V any](entries []Entry[S, V], nodeSize int) *RTree[S, V] {
+	if nodeSize < 2 {
+		nodeSize = 16
+	}
+	t := &RTree[S, V]{n: len(entries)}
+	if len(entries) == 0 {
+		return t
+	}
+
+	entries = slices.Clone(entries)
+	var level []*rtNode[S, V]
+	for _, group := range strTiles(entries, nodeSize, func(e Entry[S, V]) Rect[S] { return e.Bounds }) {
+		n := &rtNode[S, V]{entries: group, bounds: group[0].Bounds}
+		for _, e := range group {
+			n.bounds = cover(n.bounds, e.Bounds)
+			n.hasEmpty = n.hasEmpty || e.Bounds.Empty()
+		}
+		level = append(level, n)
+	}
+	for len(level) > 1 {
+		var next []*rtNode[S, V]
+		for _, group := range strTiles(level, nodeSize, func(n *rtNode[S, V]) Rect[S] { return n.bounds }) {
+			n := &rtNode[S, V]{children: group, bounds: group[0].bounds}
+			for _, c := range group {
+				n.bounds = cover(n.bounds, c.bounds)
+				n.hasEmpty = n.hasEmpty || c.hasEmpty
+			}
+			next = append(next, n)
+		}
+		level = next
+	}
+	t.root = level[0]
+	return t
+}
+
+// strTiles sorts items into vertical slices by center X, sorts each slice by
+// center Y, and groups consecutive runs of size items.
+func strTiles[T any, S ng.Scalar](items []T, size int, bounds func(T) Rect[S]) [][]T {
+	center := func(t T, y bool) float64 {
+		b := bounds(t)
+		if y {
+			return float64(b.Min.Y) + float64(b.Max.Y)
+		}
+		return float64(b.Min.X) + float64(b.Max.X)
+	}
+	byCenter := func(y bool) func(a, b T) int {
+		return func(a, b T) int {
+			ca, cb := center(a, y), center(b, y)
+			switch {
+			case ca < cb:
+				return -1
+			case ca > cb:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	nodes := (len(items) + size - 1) / size
+	perSlice := int(math.Ceil(math.Sqrt(float64(nodes)))) * size
+	slices.SortStableFunc(items, byCenter(false))
+	var groups [][]T
+	for i := 0; i < len(items); i += perSlice {
+		slice := items[i:min(i+perSlice, len(items))]
+		slices.SortStableFunc(slice, byCenter(true))
+		for j := 0; j < len(slice); j += size {
+			groups = append(groups, slice[j:min(j+size, len(slice))])
+		}
+	}
+	return groups
+}
+
+// cover returns the smallest rectangle containing the coordinates of both r
+// and s. Unlike Rect.Union, it does not ignore empty rectangles.
+func cover[S ng.Scalar](r, s Rect[S]) Rect[S] {
+	return Xyxy(
+		min(r.Min.X, s.Min.X), min(r.Min.Y, s.Min.Y),
+		max(r.Max.X, s.Max.X), max(r.Max.Y, s.Max.Y),
+	)
+}
+
+// Len returns the number of entries in the tree.
+func (t *RTree[S, V]) Len() int {
+	return t.n
+}
+
+// Bounds returns the smallest rectangle containing every entry.
+func (t *RTree[S, V]) Bounds() Rect[S] {
+	if t.root == nil {
+		return Rect[S]{}
+	}
+	return t.root.bounds
+}
+
+// All returns a sequence of all entries.
+func (t *RTree[S, V]) All() iter.Seq[Entry[S, V]] {
+	return t.search(
+		func(*rtNode[S, V]) bool { return true },
+		func(Rect[S]) bool { return true },
+	)
+}
+
+// Query returns a sequence of the entries whose bounds overlap r.
+func (t *RTree[S, V]) Query(r Rect[S]) iter.Seq[Entry[S, V]] {
+	return t.search(
+		func(n *rtNode[S, V]) bool { return n.bounds.Overlaps(r) },
+		r.Overlaps,
+	)
+}
+
+// QueryPoint returns a sequence of the entries whose bounds contain p.
+func (t *RTree[S, V]) QueryPoint(p Point[S]) iter.Seq[Entry[S, V]] {
+	return t.search(
+		func(n *rtNode[S, V]) bool { return p.In(n.bounds) },
+		p.In,
+	)
+}
+
+// Contained returns a sequence of the entries whose bounds are in r,
+// as reported by Rect.In. Entries with empty bounds are in every rectangle.
+func (t *RTree[S, V]) Contained(r Rect[S]) iter.Seq[Entry[S, V]] {
+	return t.search(
+		func(n *rtNode[S, V]) bool { return n.hasEmpty || n.bounds.Overlaps(r) },
+		func(b Rect[S]) bool { return b.In(r) },
+	)
+}
+
+// Nearest returns a sequence of all entries in increasing Euclidean distance
+// from p to their bounds. Stop after k entries for a k-nearest search.
+func (t *RTree[S, V]) Nearest(p Point[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		if t.root == nil {
+			return
+		}
+		var q nearQueue[rtNode[S, V], S, V]
+		q.push(0, t.root, Entry[S, V]{})
+		for q.Len() > 0 {
+			it := q.pop()
+			if it.node == nil {
+				if !yield(it.entry) {
+					return
+				}
+				continue
+			}
+			for _, e := range it.node.entries {
+				q.push(distSq(p, e.Bounds), nil, e)
+			}
+			for _, c := range it.node.children {
+				q.push(distSq(p, c.bounds), c, Entry[S, V]{})
+			}
+		}
+	}
+}
+
+// search yields the entries matching match under the nodes accepted by visit.
+func (t *RTree[S, V]) search(visit func(*rtNode[S, V]) bool, match func(Rect[S]) bool) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		if t.root == nil {
+			return
+		}
+		stack := []*rtNode[S, V]{t.root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !visit(n) {
+				continue
+			}
+			for _, e := range n.entries {
+				if match(e.Bounds) && !yield(e) {
+					return
+				}
+			}
+			for i := len(n.children) - 1; i >= 0; i-- {
+				stack = append(stack, n.children[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func rtreeEntries(rects []loc.Rect[int]) []loc.Entry[int, int] {
+	entries := make([]loc.Entry[int, int], len(rects))
+	for i, r := range rects {
+		entries[i] = loc.Entry[int, int]{Bounds: r, Value: i}
+	}
+	return entries
+}
+
+func TestRTree_Query(t *testing.T) {
+	rects := randomRects(1000, 4)
+	tree := loc.NewRTree(rtreeEntries(rects), 8)
+	if tree.Len() != len(rects) {
+		t.Errorf("Len mismatch, want %d, got %d", len(rects), tree.Len())
+	}
+	for _, q := range randomRects(50, 5) {
+		q = q.Inset(-60)
+		var wantOverlap, wantIn []int
+		for i, r := range rects {
+			if r.Overlaps(q) {
+				wantOverlap = append(wantOverlap, i)
+			}
+			if r.In(q) {
+				wantIn = append(wantIn, i)
+			}
+		}
+		got := values(tree.Query(q))
+		slices.Sort(got)
+		if !slices.Equal(wantOverlap, got) {
+			t.Errorf("Query(%v) mismatch, want %v, got %v", q, wantOverlap, got)
+		}
+		got = values(tree.Contained(q))
+		slices.Sort(got)
+		if !slices.Equal(wantIn, got) {
+			t.Errorf("Contained(%v) mismatch, want %v, got %v", q, wantIn, got)
+		}
+	}
+}
+
+func TestRTree_Nearest(t *testing.T) {
+	rects := randomRects(500, 6)
+	tree := loc.NewRTree(rtreeEntries(rects), 4)
+	p := loc.Xy(123, 456)
+	dist := func(r loc.Rect[int]) int {
+		dx := max(r.Min.X-p.X, 0, p.X-r.Max.X)
+		dy := max(r.Min.Y-p.Y, 0, p.Y-r.Max.Y)
+		return dx*dx + dy*dy
+	}
+	var got []int
+	for e := range tree.Nearest(p) {
+		got = append(got, dist(e.Bounds))
+		if len(got) == 20 {
+			break
+		}
+	}
+	want := make([]int, len(rects))
+	for i, r := range rects {
+		want[i] = dist(r)
+	}
+	slices.Sort(want)
+	if !slices.Equal(want[:20], got) {
+		t.Errorf("Nearest distances mismatch, want %v, got %v", want[:20], got)
+	}
+}
+
+func TestRTree_Empty(t *testing.T) {
+	tree := loc.NewRTree[int, int](nil, 0)
+	if got := values(tree.Query(loc.Xyxy(0, 0, 10, 10))); got != nil {
+		t.Errorf("Query on empty tree should yield nothing, got %v", got)
+	}
+	if !tree.Bounds().Empty() {
+		t.Errorf("Bounds of empty tree should be empty, got %v", tree.Bounds())
+	}
+}
+
+func BenchmarkRTree_Query(b *testing.B) {
+	rects := randomRects(10000, 7)
+	tree := loc.NewRTree(rtreeEntries(rects), 16)
+	queries := randomRects(100, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range tree.Query(queries[i%len(queries)]) {
+		}
+	}
+}
+
+func BenchmarkQuadtree_Query(b *testing.B) {
+	rects := randomRects(10000, 7)
+	qt := loc.NewQuadtree[int, int](loc.Xyxy(0, 0, 1024, 1024), 16, 10)
+	for i, r := range rects {
+		qt.Insert(r, i)
+	}
+	queries := randomRects(100, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range qt.Query(queries[i%len(queries)]) {
+		}
+	}
+}
+
+func BenchmarkNaive_Query(b *testing.B) {
+	rects := randomRects(10000, 7)
+	queries := randomRects(100, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := queries[i%len(queries)]
+		for _, r := range rects {
+			if r.Overlaps(q) {
+				_ = r
+			}
+		}
+	}
+}
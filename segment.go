@@ -0,0 +1,257 @@
+package loc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Segment is the line segment from A to B, both endpoints included.
+type Segment[S ng.Scalar] struct {
+	A, B Point[S]
+}
+
+// Seg is shorthand for Segment[S]{a, b}.
+func Seg[S ng.Scalar](a, b Point[S]) Segment[S] {
+	return Segment[S]{A: a, B: b}
+}
+
+// String returns a string representation of s like "(1,2)-(3,4)".
+func (s Segment[S]) String() string {
+	return fmt.Sprintf("%v-%v", s.A, s.B)
+}
+
+// Delta returns B-A.
+func (s Segment[S]) Delta() Point[S] {
+	return s.B.Sub(s.A)
+}
+
+// Len returns the length of s.
+func (s Segment[S]) Len() float64 {
+	d := s.B.Float64().Sub(s.A.Float64())
+	return math.Hypot(d.X, d.Y)
+}
+
+// Bounds returns the smallest rectangle whose closure contains s.
+func (s Segment[S]) Bounds() Rect[S] {
+	return Rect[S]{Min: s.A, Max: s.B}.Canon()
+}
+
+// At returns the point A + t*(B-A), rounded to the nearest integer for
+// integer types.
+func (s Segment[S]) At(t float64) Point[S] {
+	x, y := lerpAt(s.A, s.B.Float64().Sub(s.A.Float64()), t)
+	return Point[S]{X: roundS[S](x), Y: roundS[S](y)}
+}
+
+func lerpAt[S ng.Scalar](a Point[S], d Point[float64], t float64) (float64, float64) {
+	return float64(a.X) + t*d.X, float64(a.Y) + t*d.Y
+}
+
+// ClipRect returns the part of s inside the closure of r, the closed box
+// [Min, Max], and false if there is none. Integer rectangles are clipped the
+// same way, as by Polygon.ClipRect, so the result may end on the Max edge,
+// one past the last pixel of r.
+func (s Segment[S]) ClipRect(r Rect[S]) (Segment[S], bool) {
+	d := s.B.Float64().Sub(s.A.Float64())
+	t0, t1, ok := clipLine(s.A, d, 0, 1, r)
+	if !ok {
+		return Segment[S]{}, false
+	}
+	return Segment[S]{A: clipPoint(s.A, d, t0, r), B: clipPoint(s.A, d, t1, r)}, true
+}
+
+// clipLine clips the parameter range [t0, t1] of the line a + t*d against r
+// with the Liang–Barsky algorithm.
+func clipLine[S ng.Scalar](a Point[S], d Point[float64], t0, t1 float64, r Rect[S]) (float64, float64, bool) {
+	if r.Empty() {
+		return 0, 0, false
+	}
+	lo, hi := r.Min.Float64(), r.Max.Float64()
+	x, y := float64(a.X), float64(a.Y)
+	p := [4]float64{-d.X, d.X, -d.Y, d.Y}
+	q := [4]float64{x - lo.X, hi.X - x, y - lo.Y, hi.Y - y}
+	for i := range p {
+		if p[i] == 0 {
+			if q[i] < 0 {
+				return 0, 0, false
+			}
+			continue
+		}
+		t := q[i] / p[i]
+		if p[i] < 0 {
+			t0 = max(t0, t)
+		} else {
+			t1 = min(t1, t)
+		}
+		if t0 > t1 {
+			return 0, 0, false
+		}
+	}
+	return t0, t1, true
+}
+
+// clipPoint returns a + t*d rounded for S and clamped into the closure of r.
+func clipPoint[S ng.Scalar](a Point[S], d Point[float64], t float64, r Rect[S]) Point[S] {
+	x, y := lerpAt(a, d, t)
+	lo, hi := r.Min.Float64(), r.Max.Float64()
+	return Point[S]{
+		X: roundS[S](min(max(x, lo.X), hi.X)),
+		Y: roundS[S](min(max(y, lo.Y), hi.Y)),
+	}
+}
+
+// Intersect returns the point where s and o intersect, and false if they do
+// not. If the segments are collinear and overlap, the overlapping point
+// nearest to s.A is returned.
+func (s Segment[S]) Intersect(o Segment[S]) (Point[S], bool) {
+	t, ok := s.intersect(o)
+	if !ok {
+		return Point[S]{}, false
+	}
+	return s.At(t), true
+}
+
+// intersect returns the parameter on s of the intersection with o.
+func (s Segment[S]) intersect(o Segment[S]) (float64, bool) {
+	const eps = 1e-12
+	p, r := s.A.Float64(), s.B.Float64().Sub(s.A.Float64())
+	q, e := o.A.Float64(), o.B.Float64().Sub(o.A.Float64())
+	qp := q.Sub(p)
+	denom := cross(r, e)
+	if math.Abs(denom) > eps {
+		t := cross(qp, e) / denom
+		u := cross(qp, r) / denom
+		if t < -eps || t > 1+eps || u < -eps || u > 1+eps {
+			return 0, false
+		}
+		return min(max(t, 0), 1), true
+	}
+	if math.Abs(cross(qp, r)) > eps {
+		return 0, false // parallel
+	}
+	rr := dot(r, r)
+	if rr == 0 {
+		// s is a point; it intersects o if it lies on o.
+		if c := o.closest(p); c.Sub(p) == (Point[float64]{}) {
+			return 0, true
+		}
+		return 0, false
+	}
+	t0 := dot(qp, r) / rr
+	t1 := t0 + dot(e, r)/rr
+	lo, hi := max(min(t0, t1), 0), min(max(t0, t1), 1)
+	if lo > hi {
+		return 0, false
+	}
+	return lo, true
+}
+
+// ClosestPoint returns the point of s nearest to p, rounded to the nearest
+// integer for integer types.
+func (s Segment[S]) ClosestPoint(p Point[S]) Point[S] {
+	c := s.closest(p.Float64())
+	return Point[S]{X: roundS[S](c.X), Y: roundS[S](c.Y)}
+}
+
+func (s Segment[S]) closest(p Point[float64]) Point[float64] {
+	a, d := s.A.Float64(), s.B.Float64().Sub(s.A.Float64())
+	dd := dot(d, d)
+	if dd == 0 {
+		return a
+	}
+	t := min(max(dot(p.Sub(a), d)/dd, 0), 1)
+	x, y := lerpAt(s.A, d, t)
+	return Xy(x, y)
+}
+
+// Dist returns the Euclidean distance from p to the nearest point of s.
+func (s Segment[S]) Dist(p Point[S]) float64 {
+	d := p.Float64().Sub(s.closest(p.Float64()))
+	return math.Hypot(d.X, d.Y)
+}
+
+// Ray is the half-line starting at Origin in the direction Dir.
+type Ray[S ng.Scalar] struct {
+	Origin Point[S]
+	Dir    Point[S]
+}
+
+// String returns a string representation of r like "(1,2)+t(3,4)".
+func (r Ray[S]) String() string {
+	return fmt.Sprintf("%v+t%v", r.Origin, r.Dir)
+}
+
+// At returns the point Origin + t*Dir, rounded to the nearest integer for
+// integer types.
+func (r Ray[S]) At(t float64) Point[S] {
+	x, y := lerpAt(r.Origin, r.Dir.Float64(), t)
+	return Point[S]{X: roundS[S](x), Y: roundS[S](y)}
+}
+
+// Cast returns the smallest t >= 0 at which the ray is inside the closure of
+// rect, and false if it never is. A ray starting inside rect returns 0.
+func (r Ray[S]) Cast(rect Rect[S]) (float64, bool) {
+	t0, _, ok := clipLine(r.Origin, r.Dir.Float64(), 0, math.Inf(1), rect)
+	return t0, ok
+}
+
+// ClipRect returns the part of the ray inside rect as a segment from where it
+// enters to where it leaves. Like Segment.ClipRect, it clips to the closure
+// of rect for integer types too. A ray with zero Dir is treated as a point.
+func (r Ray[S]) ClipRect(rect Rect[S]) (Segment[S], bool) {
+	d := r.Dir.Float64()
+	t1 := math.Inf(1)
+	if d == (Point[float64]{}) {
+		t1 = 0
+	}
+	t0, t1, ok := clipLine(r.Origin, d, 0, t1, rect)
+	if !ok {
+		return Segment[S]{}, false
+	}
+	return Segment[S]{A: clipPoint(r.Origin, d, t0, rect), B: clipPoint(r.Origin, d, t1, rect)}, true
+}
+
+// Intersect returns the first point where the ray meets s, and false if it
+// does not. A ray with zero Dir is treated as a point.
+func (r Ray[S]) Intersect(s Segment[S]) (Point[S], bool) {
+	const eps = 1e-12
+	p, d := r.Origin.Float64(), r.Dir.Float64()
+	q, e := s.A.Float64(), s.B.Float64().Sub(s.A.Float64())
+	qp := q.Sub(p)
+	denom := cross(d, e)
+	if math.Abs(denom) > eps {
+		t := cross(qp, e) / denom
+		u := cross(qp, d) / denom
+		if t < -eps || u < -eps || u > 1+eps {
+			return Point[S]{}, false
+		}
+		return r.At(max(t, 0)), true
+	}
+	dd := dot(d, d)
+	if dd == 0 {
+		// The ray is a point; it intersects s if it lies on s.
+		if s.closest(p) == p {
+			return r.Origin, true
+		}
+		return Point[S]{}, false
+	}
+	if math.Abs(cross(qp, d)) > eps {
+		return Point[S]{}, false
+	}
+	t0 := dot(qp, d) / dd
+	t1 := t0 + dot(e, d)/dd
+	if max(t0, t1) < 0 {
+		return Point[S]{}, false
+	}
+	return r.At(max(min(t0, t1), 0)), true
+}
+
+func dot(p, q Point[float64]) float64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
+func cross(p, q Point[float64]) float64 {
+	return p.X*q.Y - p.Y*q.X
+}
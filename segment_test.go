@@ -0,0 +1,121 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestSegment_ClipRect(t *testing.T) {
+	r := loc.Xyxy(0.0, 0.0, 10.0, 10.0)
+	tests := []struct {
+		s    loc.Segment[float64]
+		want loc.Segment[float64]
+		ok   bool
+	}{
+		{loc.Seg(loc.Xy(-5.0, 5.0), loc.Xy(15.0, 5.0)), loc.Seg(loc.Xy(0.0, 5.0), loc.Xy(10.0, 5.0)), true},
+		{loc.Seg(loc.Xy(2.0, 2.0), loc.Xy(8.0, 3.0)), loc.Seg(loc.Xy(2.0, 2.0), loc.Xy(8.0, 3.0)), true},
+		{loc.Seg(loc.Xy(-5.0, -5.0), loc.Xy(15.0, 15.0)), loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(10.0, 10.0)), true},
+		{loc.Seg(loc.Xy(-5.0, 20.0), loc.Xy(20.0, -5.0)), loc.Seg(loc.Xy(5.0, 10.0), loc.Xy(10.0, 5.0)), true},
+		{loc.Seg(loc.Xy(-5.0, 12.0), loc.Xy(15.0, 12.0)), loc.Segment[float64]{}, false},
+		{loc.Seg(loc.Xy(-5.0, 5.0), loc.Xy(-1.0, 5.0)), loc.Segment[float64]{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.s.ClipRect(r)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ClipRect(%v) mismatch, want %v %v, got %v %v", tt.s, tt.want, tt.ok, got, ok)
+		}
+	}
+
+	// Integer segments are clipped to the closure of r, like polygons.
+	got, ok := loc.Seg(loc.Xy(-10, 3), loc.Xy(30, 3)).ClipRect(loc.Xyxy(0, 0, 10, 10))
+	if want := loc.Seg(loc.Xy(0, 3), loc.Xy(10, 3)); !ok || got != want {
+		t.Errorf("integer ClipRect mismatch, want %v, got %v", want, got)
+	}
+	got, ok = loc.Seg(loc.Xy(-10, 10), loc.Xy(30, 10)).ClipRect(loc.Xyxy(0, 0, 10, 10))
+	if want := loc.Seg(loc.Xy(0, 10), loc.Xy(10, 10)); !ok || got != want {
+		t.Errorf("integer ClipRect should keep the Max edge, got %v %v", got, ok)
+	}
+	if _, ok := loc.Seg(loc.Xy(-10, 11), loc.Xy(30, 11)).ClipRect(loc.Xyxy(0, 0, 10, 10)); ok {
+		t.Errorf("integer ClipRect beyond the Max edge should miss")
+	}
+}
+
+func TestSegment_Intersect(t *testing.T) {
+	tests := []struct {
+		s, o loc.Segment[float64]
+		want loc.Point[float64]
+		ok   bool
+	}{
+		{loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(10.0, 10.0)), loc.Seg(loc.Xy(0.0, 10.0), loc.Xy(10.0, 0.0)), loc.Xy(5.0, 5.0), true},
+		{loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(4.0, 4.0)), loc.Seg(loc.Xy(0.0, 10.0), loc.Xy(10.0, 0.0)), loc.Point[float64]{}, false},
+		{loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(10.0, 0.0)), loc.Seg(loc.Xy(0.0, 1.0), loc.Xy(10.0, 1.0)), loc.Point[float64]{}, false},
+		{loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(10.0, 0.0)), loc.Seg(loc.Xy(5.0, 0.0), loc.Xy(20.0, 0.0)), loc.Xy(5.0, 0.0), true},
+		{loc.Seg(loc.Xy(10.0, 0.0), loc.Xy(0.0, 0.0)), loc.Seg(loc.Xy(5.0, 0.0), loc.Xy(20.0, 0.0)), loc.Xy(10.0, 0.0), true},
+		{loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(10.0, 0.0)), loc.Seg(loc.Xy(10.0, 0.0), loc.Xy(10.0, 5.0)), loc.Xy(10.0, 0.0), true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.s.Intersect(tt.o)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%v.Intersect(%v) mismatch, want %v %v, got %v %v", tt.s, tt.o, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestSegment_ClosestPoint(t *testing.T) {
+	s := loc.Seg(loc.Xy(0, 0), loc.Xy(10, 0))
+	if got := s.ClosestPoint(loc.Xy(4, 7)); got != loc.Xy(4, 0) {
+		t.Errorf("ClosestPoint mismatch, got %v", got)
+	}
+	if got := s.ClosestPoint(loc.Xy(-3, -4)); got != loc.Xy(0, 0) {
+		t.Errorf("ClosestPoint before A mismatch, got %v", got)
+	}
+	if got := s.Dist(loc.Xy(13, 4)); got != 5 {
+		t.Errorf("Dist mismatch, got %v", got)
+	}
+	if got := loc.Seg(loc.Xy(1, 1), loc.Xy(1, 1)).Dist(loc.Xy(4, 5)); got != 5 {
+		t.Errorf("degenerate Dist mismatch, got %v", got)
+	}
+	if got := loc.Seg(loc.Xy(0.0, 0.0), loc.Xy(3.0, 4.0)).Len(); got != 5 {
+		t.Errorf("Len mismatch, got %v", got)
+	}
+}
+
+func TestRay(t *testing.T) {
+	r := loc.Ray[float64]{Origin: loc.Xy(-10.0, 5.0), Dir: loc.Xy(1.0, 0.0)}
+	rect := loc.Xyxy(0.0, 0.0, 10.0, 10.0)
+	if tt, ok := r.Cast(rect); !ok || tt != 10 {
+		t.Errorf("Cast mismatch, got %v %v", tt, ok)
+	}
+	got, ok := r.ClipRect(rect)
+	if want := loc.Seg(loc.Xy(0.0, 5.0), loc.Xy(10.0, 5.0)); !ok || got != want {
+		t.Errorf("Ray.ClipRect mismatch, want %v, got %v", want, got)
+	}
+	if _, ok := (loc.Ray[float64]{Origin: loc.Xy(-10.0, 5.0), Dir: loc.Xy(-1.0, 0.0)}).Cast(rect); ok {
+		t.Errorf("ray pointing away should not hit")
+	}
+	inside := loc.Ray[float64]{Origin: loc.Xy(5.0, 5.0), Dir: loc.Xy(0.0, -2.0)}
+	if tt, ok := inside.Cast(rect); !ok || tt != 0 {
+		t.Errorf("Cast from inside mismatch, got %v %v", tt, ok)
+	}
+	if got, ok := inside.ClipRect(rect); !ok || got.B != loc.Xy(5.0, 0.0) {
+		t.Errorf("Ray.ClipRect from inside mismatch, got %v", got)
+	}
+	if p, ok := r.Intersect(loc.Seg(loc.Xy(3.0, 0.0), loc.Xy(3.0, 10.0))); !ok || p != loc.Xy(3.0, 5.0) {
+		t.Errorf("Ray.Intersect mismatch, got %v %v", p, ok)
+	}
+	if _, ok := r.Intersect(loc.Seg(loc.Xy(-20.0, 0.0), loc.Xy(-20.0, 10.0))); ok {
+		t.Errorf("Ray.Intersect behind origin should miss")
+	}
+	still := loc.Ray[int]{Origin: loc.Xy(3, 3)}
+	if p, ok := still.Intersect(loc.Seg(loc.Xy(0, 0), loc.Xy(6, 6))); !ok || p != loc.Xy(3, 3) {
+		t.Errorf("zero-Dir Ray.Intersect on the segment mismatch, got %v %v", p, ok)
+	}
+	if _, ok := still.Intersect(loc.Seg(loc.Xy(0, 1), loc.Xy(6, 7))); ok {
+		t.Errorf("zero-Dir Ray.Intersect off the segment should miss")
+	}
+	diag := loc.Ray[int]{Origin: loc.Xy(-4, -4), Dir: loc.Xy(1, 1)}
+	if got, ok := diag.ClipRect(loc.Xyxy(0, 0, 8, 8)); !ok || got != loc.Seg(loc.Xy(0, 0), loc.Xy(8, 8)) {
+		t.Errorf("integer Ray.ClipRect mismatch, got %v", got)
+	}
+}
@@ -0,0 +1,121 @@
+package loc
+
+import (
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A Rounding is a policy for converting coordinates to integers.
+// Int and Image truncate toward zero instead.
+type Rounding int
+
+const (
+	RoundHalfEven Rounding = iota // to nearest, ties to even
+	RoundFloor                    // toward negative infinity
+	RoundCeil                     // toward positive infinity
+	// RoundOutward grows rectangles to the enclosing pixels: Min is floored
+	// and Max is ceiled. Points are rounded away from zero.
+	RoundOutward
+	// RoundInward shrinks rectangles to the enclosed pixels: Min is ceiled
+	// and Max is floored. Points are rounded toward zero.
+	RoundInward
+)
+
+// round rounds f; lower selects the rule for a lower bound with
+// RoundOutward and RoundInward.
+func (m Rounding) round(f float64, lower bool) float64 {
+	switch m {
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeil:
+		return math.Ceil(f)
+	case RoundOutward:
+		if lower {
+			return math.Floor(f)
+		}
+		return math.Ceil(f)
+	case RoundInward:
+		if lower {
+			return math.Ceil(f)
+		}
+		return math.Floor(f)
+	}
+	return math.RoundToEven(f)
+}
+
+// Round returns p rounded to an int point with the given policy.
+func (p Point[S]) Round(mode Rounding) Point[int] {
+	x, y := float64(p.X), float64(p.Y)
+	return Point[int]{
+		X: int(mode.round(x, x < 0)),
+		Y: int(mode.round(y, y < 0)),
+	}
+}
+
+// Round returns r rounded to an int rectangle with the given policy.
+// With RoundInward the result may be empty.
+func (r Rect[S]) Round(mode Rounding) Rect[int] {
+	return Rect[int]{
+		Min: Point[int]{
+			X: int(mode.round(float64(r.Min.X), true)),
+			Y: int(mode.round(float64(r.Min.Y), true)),
+		},
+		Max: Point[int]{
+			X: int(mode.round(float64(r.Max.X), false)),
+			Y: int(mode.round(float64(r.Max.Y), false)),
+		},
+	}
+}
+
+// Snap returns r with its edges moved to the nearest device pixel boundary
+// for the given scale factor, such as a display's device pixel ratio.
+// The result is in the same units as r.
+func (r Rect[S]) Snap(scale float64) Rect[S] {
+	snap := func(v S) S {
+		return S(math.RoundToEven(float64(v)*scale) / scale)
+	}
+	return Rect[S]{
+		Min: Point[S]{X: snap(r.Min.X), Y: snap(r.Min.Y)},
+		Max: Point[S]{X: snap(r.Max.X), Y: snap(r.Max.Y)},
+	}
+}
+
+// SnapRects converts rects, such as the cells from SplitX, to device pixels
+// at the given scale factor. Coordinates that are equal, or within floating
+// point error of each other, are rounded to the same pixel, so adjacent
+// rectangles stay adjacent without gaps or overlaps.
+func SnapRects[S ng.Scalar](rects []Rect[S], scale float64) []Rect[int] {
+	xs := snapEdges(rects, scale, func(p Point[S]) S { return p.X })
+	ys := snapEdges(rects, scale, func(p Point[S]) S { return p.Y })
+	out := make([]Rect[int], len(rects))
+	for i, r := range rects {
+		out[i] = Xyxy(
+			xs[float64(r.Min.X)*scale], ys[float64(r.Min.Y)*scale],
+			xs[float64(r.Max.X)*scale], ys[float64(r.Max.Y)*scale],
+		)
+	}
+	return out
+}
+
+// snapEdges maps every scaled coordinate of rects on one axis to a pixel,
+// rounding coordinates in a cluster of near-equal values together.
+func snapEdges[S ng.Scalar](rects []Rect[S], scale float64, axis func(Point[S]) S) map[float64]int {
+	vs := make([]float64, 0, 2*len(rects))
+	for _, r := range rects {
+		vs = append(vs, float64(axis(r.Min))*scale, float64(axis(r.Max))*scale)
+	}
+	slices.Sort(vs)
+	pixels := make(map[float64]int, len(vs))
+	start := math.Inf(-1)
+	var px int
+	for _, v := range vs {
+		if v-start > 1e-6*max(1, math.Abs(v)) {
+			start = v
+			px = int(math.RoundToEven(v))
+		}
+		pixels[v] = px
+	}
+	return pixels
+}
@@ -0,0 +1,61 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestRect_Round(t *testing.T) {
+	r := loc.Xyxy(0.5, 1.5, 10.4, 10.6)
+	tests := []struct {
+		mode loc.Rounding
+		want loc.Rect[int]
+	}{
+		{loc.RoundHalfEven, loc.Xyxy(0, 2, 10, 11)},
+		{loc.RoundFloor, loc.Xyxy(0, 1, 10, 10)},
+		{loc.RoundCeil, loc.Xyxy(1, 2, 11, 11)},
+		{loc.RoundOutward, loc.Xyxy(0, 1, 11, 11)},
+		{loc.RoundInward, loc.Xyxy(1, 2, 10, 10)},
+	}
+	for _, tt := range tests {
+		if got := r.Round(tt.mode); got != tt.want {
+			t.Errorf("Round(%d) mismatch, want %v, got %v", tt.mode, tt.want, got)
+		}
+	}
+	if got := loc.Xy(-1.5, 2.5).Round(loc.RoundOutward); got != loc.Xy(-2, 3) {
+		t.Errorf("Point.Round outward mismatch, got %v", got)
+	}
+	if got := loc.Xy(-1.5, 2.5).Round(loc.RoundInward); got != loc.Xy(-1, 2) {
+		t.Errorf("Point.Round inward mismatch, got %v", got)
+	}
+}
+
+func TestSnapRects_NoSeams(t *testing.T) {
+	for _, scale := range []float64{1, 1.25, 1.5, 2} {
+		for n := 1; n <= 13; n++ {
+			cells := loc.Xyxy(0.3, 0.0, 100.7, 10).SplitX(n, 0)
+			got := loc.SnapRects(cells, scale)
+			for i := 1; i < len(got); i++ {
+				if got[i-1].Max.X != got[i].Min.X {
+					t.Errorf("scale %v n %d: seam between %v and %v", scale, n, got[i-1], got[i])
+				}
+			}
+		}
+	}
+	// Coordinates within floating point error share a pixel.
+	got := loc.SnapRects([]loc.Rect[float64]{
+		loc.Xyxy(0.0, 0, 0.1+0.2, 1),
+		loc.Xyxy(0.3, 0, 1, 1),
+	}, 5)
+	if got[0].Max.X != got[1].Min.X {
+		t.Errorf("near-equal edges should snap together, got %v", got)
+	}
+}
+
+func TestRect_Snap(t *testing.T) {
+	got := loc.Xyxy(0.3, 0.3, 10.2, 10.9).Snap(2)
+	if want := loc.Xyxy(0.5, 0.5, 10.0, 11); got != want {
+		t.Errorf("Snap mismatch, want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,828 @@
+package loc
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// Constraint solver errors.
+var (
+	ErrDuplicateConstraint     = errors.New("loc: duplicate constraint")
+	ErrUnsatisfiableConstraint = errors.New("loc: unsatisfiable constraint")
+	ErrUnknownConstraint       = errors.New("loc: unknown constraint")
+	ErrDuplicateEditVariable   = errors.New("loc: duplicate edit variable")
+	ErrUnknownEditVariable     = errors.New("loc: unknown edit variable")
+	ErrBadRequiredStrength     = errors.New("loc: edit variable cannot be required")
+	ErrUnboundedObjective      = errors.New("loc: unbounded objective")
+)
+
+// A Strength is the priority of a constraint. Constraints weaker than
+// Required may be violated when they conflict with stronger ones.
+type Strength float64
+
+// Predefined strengths.
+const (
+	Weak     Strength = 1
+	Medium   Strength = 1e3
+	Strong   Strength = 1e6
+	Required Strength = 1001001000
+)
+
+// A Variable is a value computed by a Solver.
+type Variable struct {
+	Name  string
+	value float64
+}
+
+// NewVariable returns a new variable with the given name.
+func NewVariable(name string) *Variable {
+	return &Variable{Name: name}
+}
+
+// Value returns the value of v as of the last Solver.UpdateVariables.
+func (v *Variable) Value() float64 {
+	return v.value
+}
+
+// String returns the name of v.
+func (v *Variable) String() string {
+	return v.Name
+}
+
+// Expr returns v as an expression.
+func (v *Variable) Expr() Expression {
+	return Expression{terms: []term{{v, 1}}}
+}
+
+func (v *Variable) linear() Expression { return v.Expr() }
+
+// Add returns the expression v+e.
+func (v *Variable) Add(e Linear) Expression { return v.Expr().Add(e) }
+
+// Sub returns the expression v-e.
+func (v *Variable) Sub(e Linear) Expression { return v.Expr().Sub(e) }
+
+// Mul returns the expression v*k.
+func (v *Variable) Mul(k float64) Expression { return v.Expr().Mul(k) }
+
+// Plus returns the expression v+c.
+func (v *Variable) Plus(c float64) Expression { return v.Expr().Plus(c) }
+
+// Eq returns the required constraint v == e.
+func (v *Variable) Eq(e Linear) *Constraint { return v.Expr().Eq(e) }
+
+// Le returns the required constraint v <= e.
+func (v *Variable) Le(e Linear) *Constraint { return v.Expr().Le(e) }
+
+// Ge returns the required constraint v >= e.
+func (v *Variable) Ge(e Linear) *Constraint { return v.Expr().Ge(e) }
+
+// Linear is implemented by *Variable and Expression.
+type Linear interface {
+	linear() Expression
+}
+
+type term struct {
+	v     *Variable
+	coeff float64
+}
+
+// An Expression is a linear combination of variables plus a constant.
+type Expression struct {
+	terms    []term
+	constant float64
+}
+
+// Const returns the constant expression c.
+func Const(c float64) Expression {
+	return Expression{constant: c}
+}
+
+func (e Expression) linear() Expression { return e }
+
+// Add returns the expression e+f.
+func (e Expression) Add(f Linear) Expression {
+	g := f.linear()
+	return Expression{
+		terms:    append(slices.Clip(e.terms), g.terms...),
+		constant: e.constant + g.constant,
+	}
+}
+
+// Sub returns the expression e-f.
+func (e Expression) Sub(f Linear) Expression {
+	return e.Add(f.linear().Mul(-1))
+}
+
+// Mul returns the expression e*k.
+func (e Expression) Mul(k float64) Expression {
+	terms := make([]term, len(e.terms))
+	for i, t := range e.terms {
+		terms[i] = term{t.v, t.coeff * k}
+	}
+	return Expression{terms: terms, constant: e.constant * k}
+}
+
+// Plus returns the expression e+c.
+func (e Expression) Plus(c float64) Expression {
+	e.constant += c
+	return e
+}
+
+// Value returns the value of e using the current values of its variables.
+func (e Expression) Value() float64 {
+	v := e.constant
+	for _, t := range e.terms {
+		v += t.coeff * t.v.value
+	}
+	return v
+}
+
+// Eq returns the required constraint e == f.
+func (e Expression) Eq(f Linear) *Constraint { return newConstraint(e, f, opEq) }
+
+// Le returns the required constraint e <= f.
+func (e Expression) Le(f Linear) *Constraint { return newConstraint(e, f, opLe) }
+
+// Ge returns the required constraint e >= f.
+func (e Expression) Ge(f Linear) *Constraint { return newConstraint(e, f, opGe) }
+
+type relation int
+
+const (
+	opEq relation = iota
+	opLe
+	opGe
+)
+
+// A Constraint is a linear relation between expressions.
+type Constraint struct {
+	expr     Expression // expr op 0
+	op       relation
+	strength Strength
+}
+
+func newConstraint(lhs Expression, rhs Linear, op relation) *Constraint {
+	return &Constraint{expr: lhs.Sub(rhs), op: op, strength: Required}
+}
+
+// WithStrength sets the strength of c and returns c.
+// It must be called before c is added to a Solver.
+func (c *Constraint) WithStrength(s Strength) *Constraint {
+	c.strength = min(max(s, 0), Required)
+	return c
+}
+
+// Strength returns the strength of c.
+func (c *Constraint) Strength() Strength {
+	return c.strength
+}
+
+type symbolKind int
+
+const (
+	symInvalid symbolKind = iota
+	symExternal
+	symSlack
+	symError
+	symDummy
+)
+
+type symbol struct {
+	id   int
+	kind symbolKind
+}
+
+// A row is a linear equation in the simplex tableau: basic = constant + Σ cells.
+type row struct {
+	cells    map[symbol]float64
+	constant float64
+}
+
+func newRow(constant float64) *row {
+	return &row{cells: map[symbol]float64{}, constant: constant}
+}
+
+func (r *row) copy() *row {
+	c := newRow(r.constant)
+	for s, v := range r.cells {
+		c.cells[s] = v
+	}
+	return c
+}
+
+func (r *row) add(v float64) float64 {
+	r.constant += v
+	return r.constant
+}
+
+func (r *row) insertSymbol(s symbol, coeff float64) {
+	c := r.cells[s] + coeff
+	if nearZero(c) {
+		delete(r.cells, s)
+	} else {
+		r.cells[s] = c
+	}
+}
+
+func (r *row) insertRow(other *row, coeff float64) {
+	r.constant += other.constant * coeff
+	for s, c := range other.cells {
+		r.insertSymbol(s, c*coeff)
+	}
+}
+
+func (r *row) reverseSign() {
+	r.constant = -r.constant
+	for s, c := range r.cells {
+		r.cells[s] = -c
+	}
+}
+
+// solveFor solves the row for s, which must be in the row.
+func (r *row) solveFor(s symbol) {
+	coeff := -1 / r.cells[s]
+	delete(r.cells, s)
+	r.constant *= coeff
+	for t, c := range r.cells {
+		r.cells[t] = c * coeff
+	}
+}
+
+// solveForPair solves the row lhs = constant + Σ cells for rhs.
+func (r *row) solveForPair(lhs, rhs symbol) {
+	r.insertSymbol(lhs, -1)
+	r.solveFor(rhs)
+}
+
+func (r *row) substitute(s symbol, other *row) {
+	if c, ok := r.cells[s]; ok {
+		delete(r.cells, s)
+		r.insertRow(other, c)
+	}
+}
+
+// symbols returns the symbols of r in creation order, for determinism.
+func (r *row) symbols() []symbol {
+	syms := make([]symbol, 0, len(r.cells))
+	for s := range r.cells {
+		syms = append(syms, s)
+	}
+	slices.SortFunc(syms, func(a, b symbol) int { return a.id - b.id })
+	return syms
+}
+
+func nearZero(v float64) bool {
+	return math.Abs(v) < 1e-8
+}
+
+type tag struct {
+	marker, other symbol
+}
+
+type edit struct {
+	tag      tag
+	c        *Constraint
+	constant float64
+}
+
+// A Solver finds values of variables satisfying a set of constraints with the
+// Cassowary incremental simplex algorithm. The zero Solver is ready to use.
+type Solver struct {
+	constraints map[*Constraint]tag
+	rows        map[symbol]*row
+	vars        map[*Variable]symbol
+	edits       map[*Variable]*edit
+	infeasible  []symbol
+	objective   *row
+	artificial  *row
+	nextID      int
+}
+
+func (s *Solver) init() {
+	if s.objective != nil {
+		return
+	}
+	s.constraints = map[*Constraint]tag{}
+	s.rows = map[symbol]*row{}
+	s.vars = map[*Variable]symbol{}
+	s.edits = map[*Variable]*edit{}
+	s.objective = newRow(0)
+}
+
+func (s *Solver) newSymbol(kind symbolKind) symbol {
+	s.nextID++
+	return symbol{id: s.nextID, kind: kind}
+}
+
+// basics returns the basic symbols in creation order, for determinism.
+func (s *Solver) basics() []symbol {
+	syms := make([]symbol, 0, len(s.rows))
+	for sym := range s.rows {
+		syms = append(syms, sym)
+	}
+	slices.SortFunc(syms, func(a, b symbol) int { return a.id - b.id })
+	return syms
+}
+
+// AddConstraints adds each constraint in turn, stopping at the first error.
+func (s *Solver) AddConstraints(cs ...*Constraint) error {
+	for _, c := range cs {
+		if err := s.AddConstraint(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddConstraint adds c to the solver.
+func (s *Solver) AddConstraint(c *Constraint) error {
+	s.init()
+	if _, ok := s.constraints[c]; ok {
+		return ErrDuplicateConstraint
+	}
+	t, r := s.createRow(c)
+	subject := chooseSubject(r, t)
+	if subject.kind == symInvalid && allDummies(r) {
+		if !nearZero(r.constant) {
+			return ErrUnsatisfiableConstraint
+		}
+		subject = t.marker
+	}
+	if subject.kind == symInvalid {
+		ok, err := s.addWithArtificialVariable(r)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrUnsatisfiableConstraint
+		}
+	} else {
+		r.solveFor(subject)
+		s.substitute(subject, r)
+		s.rows[subject] = r
+	}
+	s.constraints[c] = t
+	return s.optimize(s.objective)
+}
+
+// RemoveConstraint removes c from the solver.
+func (s *Solver) RemoveConstraint(c *Constraint) error {
+	s.init()
+	t, ok := s.constraints[c]
+	if !ok {
+		return ErrUnknownConstraint
+	}
+	delete(s.constraints, c)
+	if t.marker.kind == symError {
+		s.removeMarkerEffects(t.marker, c.strength)
+	}
+	if t.other.kind == symError {
+		s.removeMarkerEffects(t.other, c.strength)
+	}
+	if _, ok := s.rows[t.marker]; ok {
+		delete(s.rows, t.marker)
+	} else {
+		leaving, ok := s.markerLeavingRow(t.marker)
+		if !ok {
+			return ErrUnknownConstraint
+		}
+		r := s.rows[leaving]
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, t.marker)
+		s.substitute(t.marker, r)
+	}
+	return s.optimize(s.objective)
+}
+
+// HasConstraint reports whether c has been added to the solver.
+func (s *Solver) HasConstraint(c *Constraint) bool {
+	_, ok := s.constraints[c]
+	return ok
+}
+
+// AddEditVariable makes v suggestible with SuggestValue at the given
+// strength, which must be weaker than Required.
+func (s *Solver) AddEditVariable(v *Variable, strength Strength) error {
+	s.init()
+	if _, ok := s.edits[v]; ok {
+		return ErrDuplicateEditVariable
+	}
+	if strength >= Required {
+		return ErrBadRequiredStrength
+	}
+	c := v.Eq(Const(0)).WithStrength(strength)
+	if err := s.AddConstraint(c); err != nil {
+		return err
+	}
+	s.edits[v] = &edit{tag: s.constraints[c], c: c}
+	return nil
+}
+
+// RemoveEditVariable stops v from being suggestible.
+func (s *Solver) RemoveEditVariable(v *Variable) error {
+	s.init()
+	e, ok := s.edits[v]
+	if !ok {
+		return ErrUnknownEditVariable
+	}
+	delete(s.edits, v)
+	return s.RemoveConstraint(e.c)
+}
+
+// HasEditVariable reports whether v is an edit variable.
+func (s *Solver) HasEditVariable(v *Variable) bool {
+	_, ok := s.edits[v]
+	return ok
+}
+
+// SuggestValue suggests a value for the edit variable v and re-solves
+// incrementally.
+func (s *Solver) SuggestValue(v *Variable, value float64) error {
+	s.init()
+	e, ok := s.edits[v]
+	if !ok {
+		return ErrUnknownEditVariable
+	}
+	delta := value - e.constant
+	e.constant = value
+
+	if r, ok := s.rows[e.tag.marker]; ok {
+		if r.add(-delta) < 0 {
+			s.infeasible = append(s.infeasible, e.tag.marker)
+		}
+		return s.dualOptimize()
+	}
+	if r, ok := s.rows[e.tag.other]; ok {
+		if r.add(delta) < 0 {
+			s.infeasible = append(s.infeasible, e.tag.other)
+		}
+		return s.dualOptimize()
+	}
+	for _, sym := range s.basics() {
+		r := s.rows[sym]
+		if c := r.cells[e.tag.marker]; c != 0 && r.add(delta*c) < 0 && sym.kind != symExternal {
+			s.infeasible = append(s.infeasible, sym)
+		}
+	}
+	return s.dualOptimize()
+}
+
+// UpdateVariables writes the solved values back to the variables.
+func (s *Solver) UpdateVariables() {
+	for v, sym := range s.vars {
+		if r, ok := s.rows[sym]; ok {
+			v.value = r.constant
+		} else {
+			v.value = 0
+		}
+	}
+}
+
+func (s *Solver) varSymbol(v *Variable) symbol {
+	if sym, ok := s.vars[v]; ok {
+		return sym
+	}
+	sym := s.newSymbol(symExternal)
+	s.vars[v] = sym
+	return sym
+}
+
+// createRow builds the tableau row for c with its slack and error symbols.
+func (s *Solver) createRow(c *Constraint) (tag, *row) {
+	r := newRow(c.expr.constant)
+	for _, t := range c.expr.terms {
+		if nearZero(t.coeff) {
+			continue
+		}
+		sym := s.varSymbol(t.v)
+		if basic, ok := s.rows[sym]; ok {
+			r.insertRow(basic, t.coeff)
+		} else {
+			r.insertSymbol(sym, t.coeff)
+		}
+	}
+
+	var tg tag
+	strength := float64(c.strength)
+	switch c.op {
+	case opLe, opGe:
+		coeff := 1.0
+		if c.op == opGe {
+			coeff = -1
+		}
+		slack := s.newSymbol(symSlack)
+		tg.marker = slack
+		r.insertSymbol(slack, coeff)
+		if c.strength < Required {
+			e := s.newSymbol(symError)
+			tg.other = e
+			r.insertSymbol(e, -coeff)
+			s.objective.insertSymbol(e, strength)
+		}
+	case opEq:
+		if c.strength < Required {
+			plus := s.newSymbol(symError)
+			minus := s.newSymbol(symError)
+			tg.marker, tg.other = plus, minus
+			r.insertSymbol(plus, -1)
+			r.insertSymbol(minus, 1)
+			s.objective.insertSymbol(plus, strength)
+			s.objective.insertSymbol(minus, strength)
+		} else {
+			dummy := s.newSymbol(symDummy)
+			tg.marker = dummy
+			r.insertSymbol(dummy, 1)
+		}
+	}
+	if r.constant < 0 {
+		r.reverseSign()
+	}
+	return tg, r
+}
+
+// chooseSubject picks the symbol to solve a new row for, preferring external
+// variables, then negative slack or error markers.
+func chooseSubject(r *row, t tag) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == symExternal {
+			return sym
+		}
+	}
+	for _, m := range []symbol{t.marker, t.other} {
+		if (m.kind == symSlack || m.kind == symError) && r.cells[m] < 0 {
+			return m
+		}
+	}
+	return symbol{}
+}
+
+func allDummies(r *row) bool {
+	for sym := range r.cells {
+		if sym.kind != symDummy {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Solver) addWithArtificialVariable(r *row) (bool, error) {
+	art := s.newSymbol(symSlack)
+	s.rows[art] = r.copy()
+	s.artificial = r.copy()
+	if err := s.optimize(s.artificial); err != nil {
+		return false, err
+	}
+	success := nearZero(s.artificial.constant)
+	s.artificial = nil
+
+	if basic, ok := s.rows[art]; ok {
+		delete(s.rows, art)
+		if len(basic.cells) == 0 {
+			return success, nil
+		}
+		entering := anyPivotableSymbol(basic)
+		if entering.kind == symInvalid {
+			return false, nil
+		}
+		basic.solveForPair(art, entering)
+		s.substitute(entering, basic)
+		s.rows[entering] = basic
+	}
+	for _, r := range s.rows {
+		delete(r.cells, art)
+	}
+	delete(s.objective.cells, art)
+	return success, nil
+}
+
+func (s *Solver) substitute(sym symbol, r *row) {
+	for _, basic := range s.basics() {
+		br := s.rows[basic]
+		br.substitute(sym, r)
+		if basic.kind != symExternal && br.constant < 0 {
+			s.infeasible = append(s.infeasible, basic)
+		}
+	}
+	s.objective.substitute(sym, r)
+	if s.artificial != nil {
+		s.artificial.substitute(sym, r)
+	}
+}
+
+// optimize runs the primal simplex until objective is minimized.
+func (s *Solver) optimize(objective *row) error {
+	for {
+		entering := enteringSymbol(objective)
+		if entering.kind == symInvalid {
+			return nil
+		}
+		leaving, ok := s.leavingRow(entering)
+		if !ok {
+			return ErrUnboundedObjective
+		}
+		r := s.rows[leaving]
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+}
+
+// dualOptimize restores feasibility after edit suggestions.
+func (s *Solver) dualOptimize() error {
+	for len(s.infeasible) > 0 {
+		leaving := s.infeasible[len(s.infeasible)-1]
+		s.infeasible = s.infeasible[:len(s.infeasible)-1]
+		r, ok := s.rows[leaving]
+		if !ok || nearZero(r.constant) || r.constant >= 0 {
+			continue
+		}
+		entering := s.dualEnteringSymbol(r)
+		if entering.kind == symInvalid {
+			return ErrUnboundedObjective
+		}
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+	return nil
+}
+
+func enteringSymbol(objective *row) symbol {
+	for _, sym := range objective.symbols() {
+		if sym.kind != symDummy && objective.cells[sym] < 0 {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+func (s *Solver) dualEnteringSymbol(r *row) symbol {
+	var entering symbol
+	ratio := math.MaxFloat64
+	for _, sym := range r.symbols() {
+		c := r.cells[sym]
+		if c > 0 && sym.kind != symDummy {
+			if q := s.objective.cells[sym] / c; q < ratio {
+				ratio, entering = q, sym
+			}
+		}
+	}
+	return entering
+}
+
+func anyPivotableSymbol(r *row) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == symSlack || sym.kind == symError {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+func (s *Solver) leavingRow(entering symbol) (symbol, bool) {
+	var leaving symbol
+	ratio := math.MaxFloat64
+	found := false
+	for _, sym := range s.basics() {
+		if sym.kind == symExternal {
+			continue
+		}
+		r := s.rows[sym]
+		if c := r.cells[entering]; c < 0 {
+			if q := -r.constant / c; q < ratio {
+				ratio, leaving, found = q, sym, true
+			}
+		}
+	}
+	return leaving, found
+}
+
+// markerLeavingRow finds the row to pivot out when removing a constraint
+// whose marker is not basic.
+func (s *Solver) markerLeavingRow(marker symbol) (symbol, bool) {
+	r1, r2 := math.MaxFloat64, math.MaxFloat64
+	var first, second, third symbol
+	for _, sym := range s.basics() {
+		r := s.rows[sym]
+		c := r.cells[marker]
+		switch {
+		case c == 0:
+		case sym.kind == symExternal:
+			third = sym
+		case c < 0:
+			if q := -r.constant / c; q < r1 {
+				r1, first = q, sym
+			}
+		default:
+			if q := r.constant / c; q < r2 {
+				r2, second = q, sym
+			}
+		}
+	}
+	for _, sym := range []symbol{first, second, third} {
+		if sym.kind != symInvalid {
+			return sym, true
+		}
+	}
+	return symbol{}, false
+}
+
+func (s *Solver) removeMarkerEffects(marker symbol, strength Strength) {
+	if r, ok := s.rows[marker]; ok {
+		s.objective.insertRow(r, -float64(strength))
+	} else {
+		s.objective.insertSymbol(marker, -float64(strength))
+	}
+}
+
+// RectVars binds solver variables to the edges of a rectangle.
+type RectVars[S ng.Scalar] struct {
+	Left, Top, Right, Bottom *Variable
+}
+
+// NewRectVars returns new variables for the edges of a rectangle.
+func NewRectVars[S ng.Scalar](name string) RectVars[S] {
+	return RectVars[S]{
+		Left:   NewVariable(name + ".left"),
+		Top:    NewVariable(name + ".top"),
+		Right:  NewVariable(name + ".right"),
+		Bottom: NewVariable(name + ".bottom"),
+	}
+}
+
+// Width returns the expression Right-Left.
+func (rv RectVars[S]) Width() Expression {
+	return rv.Right.Sub(rv.Left)
+}
+
+// Height returns the expression Bottom-Top.
+func (rv RectVars[S]) Height() Expression {
+	return rv.Bottom.Sub(rv.Top)
+}
+
+// Anchor returns expressions for the point at the relative position (rx, ry),
+// like Rect.Anchor.
+func (rv RectVars[S]) Anchor(rx, ry float64) (x, y Expression) {
+	x = rv.Left.Mul(1 - rx).Add(rv.Right.Mul(rx))
+	y = rv.Top.Mul(1 - ry).Add(rv.Bottom.Mul(ry))
+	return x, y
+}
+
+// Eq returns the required constraints that make the edges equal to r.
+func (rv RectVars[S]) Eq(r Rect[S]) []*Constraint {
+	return []*Constraint{
+		rv.Left.Eq(Const(float64(r.Min.X))),
+		rv.Top.Eq(Const(float64(r.Min.Y))),
+		rv.Right.Eq(Const(float64(r.Max.X))),
+		rv.Bottom.Eq(Const(float64(r.Max.Y))),
+	}
+}
+
+// Edit makes every edge of rv an edit variable of s at the given strength.
+func (rv RectVars[S]) Edit(s *Solver, strength Strength) error {
+	for _, v := range rv.vars() {
+		if err := s.AddEditVariable(v, strength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Suggest suggests r for the edges of rv, which must be edit variables of s.
+func (rv RectVars[S]) Suggest(s *Solver, r Rect[S]) error {
+	values := []S{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y}
+	for i, v := range rv.vars() {
+		if err := s.SuggestValue(v, float64(values[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rect returns the solved rectangle. For integer S the edges are rounded.
+func (rv RectVars[S]) Rect() Rect[S] {
+	return Xyxy(
+		roundS[S](rv.Left.Value()),
+		roundS[S](rv.Top.Value()),
+		roundS[S](rv.Right.Value()),
+		roundS[S](rv.Bottom.Value()),
+	)
+}
+
+func (rv RectVars[S]) vars() []*Variable {
+	return []*Variable{rv.Left, rv.Top, rv.Right, rv.Bottom}
+}
+
+// roundS converts f to S, rounding to nearest for integer S.
+func roundS[S ng.Scalar](f float64) S {
+	if isInt[S]() {
+		return S(math.Round(f))
+	}
+	return S(f)
+}
@@ -0,0 +1,133 @@
+package loc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestSolver_Panels(t *testing.T) {
+	var s loc.Solver
+	window := loc.NewRectVars[int]("window")
+	left := loc.NewRectVars[int]("left")
+	right := loc.NewRectVars[int]("right")
+
+	err := s.AddConstraints(window.Eq(loc.Xyxy(0, 0, 800, 600))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.AddConstraints(
+		left.Left.Eq(window.Left),
+		left.Top.Eq(window.Top),
+		left.Bottom.Eq(window.Bottom),
+		right.Left.Eq(left.Right.Plus(8)),
+		right.Right.Eq(window.Right),
+		right.Top.Eq(window.Top),
+		right.Bottom.Eq(window.Bottom),
+		right.Width().Ge(loc.Const(200)),
+		left.Width().Eq(loc.Const(300)).WithStrength(loc.Strong),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.UpdateVariables()
+	if want, got := loc.Xyxy(0, 0, 300, 600), left.Rect(); want != got {
+		t.Errorf("left mismatch, want %v, got %v", want, got)
+	}
+	if want, got := loc.Xyxy(308, 0, 800, 600), right.Rect(); want != got {
+		t.Errorf("right mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestSolver_Resize(t *testing.T) {
+	var s loc.Solver
+	window := loc.NewRectVars[int]("window")
+	left := loc.NewRectVars[int]("left")
+	right := loc.NewRectVars[int]("right")
+
+	if err := window.Edit(&s, loc.Strong); err != nil {
+		t.Fatal(err)
+	}
+	err := s.AddConstraints(
+		left.Left.Eq(window.Left),
+		left.Top.Eq(window.Top),
+		left.Bottom.Eq(window.Bottom),
+		right.Left.Eq(left.Right.Plus(8)),
+		right.Right.Eq(window.Right),
+		right.Top.Eq(window.Top),
+		right.Bottom.Eq(window.Bottom),
+		right.Width().Ge(loc.Const(200)),
+		left.Width().Eq(loc.Const(300)).WithStrength(loc.Medium),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		window      loc.Rect[int]
+		left, right loc.Rect[int]
+	}{
+		{loc.Xyxy(0, 0, 800, 600), loc.Xyxy(0, 0, 300, 600), loc.Xyxy(308, 0, 800, 600)},
+		{loc.Xyxy(0, 0, 400, 300), loc.Xyxy(0, 0, 192, 300), loc.Xyxy(200, 0, 400, 300)},
+		{loc.Xyxy(10, 10, 1010, 110), loc.Xyxy(10, 10, 310, 110), loc.Xyxy(318, 10, 1010, 110)},
+	}
+	for _, tt := range tests {
+		if err := window.Suggest(&s, tt.window); err != nil {
+			t.Fatal(err)
+		}
+		s.UpdateVariables()
+		if got := left.Rect(); got != tt.left {
+			t.Errorf("window %v: left mismatch, want %v, got %v", tt.window, tt.left, got)
+		}
+		if got := right.Rect(); got != tt.right {
+			t.Errorf("window %v: right mismatch, want %v, got %v", tt.window, tt.right, got)
+		}
+	}
+}
+
+func TestSolver_Anchor(t *testing.T) {
+	var s loc.Solver
+	box := loc.NewRectVars[float64]("box")
+	x, y := box.Anchor(0.5, 0.5)
+	err := s.AddConstraints(
+		x.Eq(loc.Const(100)),
+		y.Eq(loc.Const(50)),
+		box.Width().Eq(loc.Const(40)),
+		box.Height().Eq(loc.Const(20)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.UpdateVariables()
+	if want, got := loc.Xyxy(80.0, 40, 120, 60), box.Rect(); want != got {
+		t.Errorf("box mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestSolver_Errors(t *testing.T) {
+	var s loc.Solver
+	v := loc.NewVariable("v")
+	c := v.Eq(loc.Const(1))
+	if err := s.AddConstraint(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddConstraint(c); !errors.Is(err, loc.ErrDuplicateConstraint) {
+		t.Errorf("duplicate AddConstraint error mismatch, got %v", err)
+	}
+	if err := s.AddConstraint(v.Eq(loc.Const(2))); !errors.Is(err, loc.ErrUnsatisfiableConstraint) {
+		t.Errorf("conflicting AddConstraint error mismatch, got %v", err)
+	}
+	if err := s.RemoveConstraint(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RemoveConstraint(c); !errors.Is(err, loc.ErrUnknownConstraint) {
+		t.Errorf("RemoveConstraint error mismatch, got %v", err)
+	}
+	if err := s.AddEditVariable(v, loc.Required); !errors.Is(err, loc.ErrBadRequiredStrength) {
+		t.Errorf("AddEditVariable error mismatch, got %v", err)
+	}
+	if err := s.SuggestValue(v, 3); !errors.Is(err, loc.ErrUnknownEditVariable) {
+		t.Errorf("SuggestValue error mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package loc
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A SpatialHash is a uniform grid of square cells for broad-phase collision
+// detection. Each entry is registered in every cell its bounds touch.
+// Each value is stored at most once; inserting a value again moves it.
+type SpatialHash[S ng.Scalar, V comparable] struct {
+	size    S
+	cells   map[Point[int]][]V
+	entries map[V]Rect[S]
+}
+
+// NewSpatialHash returns an empty spatial hash with the given cell size,
+// which must be positive.
+func NewSpatialHash[S ng.Scalar, V comparable](cellSize S) *SpatialHash[S, V] {
+	return &SpatialHash[S, V]{
+		size:    cellSize,
+		cells:   map[Point[int]][]V{},
+		entries: map[V]Rect[S]{},
+	}
+}
+
+// CellSize returns the size of the cells.
+func (h *SpatialHash[S, V]) CellSize() S {
+	return h.size
+}
+
+// Len returns the number of entries.
+func (h *SpatialHash[S, V]) Len() int {
+	return len(h.entries)
+}
+
+// CellsOf returns the rectangle of cell coordinates covered by r. A cell
+// (x, y) spans x*size <= X < (x+1)*size and likewise for Y. An empty r covers
+// the cell of r.Min.
+func (h *SpatialHash[S, V]) CellsOf(r Rect[S]) Rect[int] {
+	size := float64(h.size)
+	c := Rect[int]{
+		Min: Point[int]{
+			X: int(math.Floor(float64(r.Min.X) / size)),
+			Y: int(math.Floor(float64(r.Min.Y) / size)),
+		},
+		Max: Point[int]{
+			X: int(math.Ceil(float64(r.Max.X) / size)),
+			Y: int(math.Ceil(float64(r.Max.Y) / size)),
+		},
+	}
+	c.Max.X = max(c.Max.X, c.Min.X+1)
+	c.Max.Y = max(c.Max.Y, c.Min.Y+1)
+	return c
+}
+
+// Insert adds v with bounds r, or moves v to r if it is already present.
+func (h *SpatialHash[S, V]) Insert(r Rect[S], v V) {
+	if _, ok := h.entries[v]; ok {
+		h.Update(v, r)
+		return
+	}
+	h.entries[v] = r
+	for c := range h.CellsOf(r).Points() {
+		h.cells[c] = append(h.cells[c], v)
+	}
+}
+
+// Remove removes v and reports whether it was present.
+func (h *SpatialHash[S, V]) Remove(v V) bool {
+	r, ok := h.entries[v]
+	if !ok {
+		return false
+	}
+	delete(h.entries, v)
+	h.unlink(v, h.CellsOf(r), Rect[int]{})
+	return true
+}
+
+// Update moves v to r and reports whether v was present. Only the cells
+// that v enters or leaves are touched.
+func (h *SpatialHash[S, V]) Update(v V, r Rect[S]) bool {
+	old, ok := h.entries[v]
+	if !ok {
+		return false
+	}
+	h.entries[v] = r
+	from, to := h.CellsOf(old), h.CellsOf(r)
+	if from == to {
+		return true
+	}
+	h.unlink(v, from, to)
+	for c := range to.Points() {
+		if !c.In(from) {
+			h.cells[c] = append(h.cells[c], v)
+		}
+	}
+	return true
+}
+
+// unlink removes v from the cells in from that are not in keep.
+func (h *SpatialHash[S, V]) unlink(v V, from, keep Rect[int]) {
+	for c := range from.Points() {
+		if c.In(keep) {
+			continue
+		}
+		vs := slices.DeleteFunc(h.cells[c], func(w V) bool { return w == v })
+		if len(vs) == 0 {
+			delete(h.cells, c)
+		} else {
+			h.cells[c] = vs
+		}
+	}
+}
+
+// Get returns the bounds of v and reports whether v is present.
+func (h *SpatialHash[S, V]) Get(v V) (Rect[S], bool) {
+	r, ok := h.entries[v]
+	return r, ok
+}
+
+// Query returns a sequence of the entries whose bounds overlap r.
+func (h *SpatialHash[S, V]) Query(r Rect[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		if r.Empty() {
+			return
+		}
+		cells := h.CellsOf(r)
+		for c := range cells.Points() {
+			for _, v := range h.cells[c] {
+				b := h.entries[v]
+				// Report each entry only from the first cell it shares with r.
+				if h.firstShared(cells, b) == c && b.Overlaps(r) {
+					if !yield(Entry[S, V]{Bounds: b, Value: v}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// QueryPoint returns a sequence of the entries whose bounds contain p.
+func (h *SpatialHash[S, V]) QueryPoint(p Point[S]) iter.Seq[Entry[S, V]] {
+	return func(yield func(Entry[S, V]) bool) {
+		c := h.CellsOf(Rect[S]{Min: p, Max: p}).Min
+		for _, v := range h.cells[c] {
+			if b := h.entries[v]; p.In(b) && !yield(Entry[S, V]{Bounds: b, Value: v}) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs returns a sequence of the pairs of entries that share a cell and
+// may therefore overlap. Each pair is reported once, in no particular order.
+func (h *SpatialHash[S, V]) Pairs() iter.Seq2[Entry[S, V], Entry[S, V]] {
+	return func(yield func(Entry[S, V], Entry[S, V]) bool) {
+		for c, vs := range h.cells {
+			for i, v := range vs {
+				a := h.entries[v]
+				ca := h.CellsOf(a)
+				for _, w := range vs[i+1:] {
+					b := h.entries[w]
+					if h.firstShared(ca, b) != c {
+						continue
+					}
+					if !yield(Entry[S, V]{Bounds: a, Value: v}, Entry[S, V]{Bounds: b, Value: w}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// firstShared returns the top-left cell shared by cells and the cells of b.
+func (h *SpatialHash[S, V]) firstShared(cells Rect[int], b Rect[S]) Point[int] {
+	cb := h.CellsOf(b)
+	return Xy(max(cells.Min.X, cb.Min.X), max(cells.Min.Y, cb.Min.Y))
+}
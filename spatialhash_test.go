@@ -0,0 +1,74 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestSpatialHash_Query(t *testing.T) {
+	rects := randomRects(500, 9)
+	h := loc.NewSpatialHash[int, int](32)
+	for i, r := range rects {
+		h.Insert(r.Sub(loc.Xy(500, 500)), i) // cover negative cells too
+	}
+	for _, q := range randomRects(50, 10) {
+		q = q.Sub(loc.Xy(500, 500)).Inset(-40)
+		var want []int
+		for i, r := range rects {
+			if r.Sub(loc.Xy(500, 500)).Overlaps(q) {
+				want = append(want, i)
+			}
+		}
+		got := values(h.Query(q))
+		slices.Sort(got)
+		if !slices.Equal(want, got) {
+			t.Errorf("Query(%v) mismatch, want %v, got %v", q, want, got)
+		}
+	}
+}
+
+func TestSpatialHash_Pairs(t *testing.T) {
+	rects := randomRects(300, 11)
+	h := loc.NewSpatialHash[int, int](50)
+	for i, r := range rects {
+		h.Insert(r, i)
+	}
+	seen := map[[2]int]bool{}
+	for a, b := range h.Pairs() {
+		key := [2]int{min(a.Value, b.Value), max(a.Value, b.Value)}
+		if seen[key] {
+			t.Errorf("Pairs reported %v twice", key)
+		}
+		seen[key] = true
+	}
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if rects[i].Overlaps(rects[j]) && !seen[[2]int{i, j}] {
+				t.Errorf("Pairs missed overlapping pair %d, %d", i, j)
+			}
+		}
+	}
+}
+
+func TestSpatialHash_Move(t *testing.T) {
+	h := loc.NewSpatialHash[float64, string](10)
+	h.Insert(loc.Xywh(1.0, 1, 5, 5), "a")
+	h.Insert(loc.Xywh(3.0, 3, 5, 5), "b")
+	if !h.Update("a", loc.Xywh(45.0, 45, 20, 20)) {
+		t.Errorf("Update(a) should succeed")
+	}
+	if got := values(h.QueryPoint(loc.Xy(2.0, 2))); len(got) != 0 {
+		t.Errorf("QueryPoint after Update should be empty, got %v", got)
+	}
+	if got := values(h.QueryPoint(loc.Xy(60.0, 50))); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("QueryPoint after Update mismatch, got %v", got)
+	}
+	for range h.Pairs() {
+		t.Errorf("Pairs should be empty after moving apart")
+	}
+	if !h.Remove("a") || h.Len() != 1 {
+		t.Errorf("Remove(a) should leave one entry")
+	}
+}
@@ -0,0 +1,214 @@
+package loc
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Hit describes the first contact of a rectangle moving through obstacles.
+type Hit[S ng.Scalar] struct {
+	// Time is the fraction of the velocity travelled before the contact, in
+	// [0, 1).
+	Time float64
+	// Normal is the outward normal of the surface hit: one of (-1,0), (1,0),
+	// (0,-1) and (0,1).
+	Normal Point[int]
+	// Index is the index of the obstacle hit.
+	Index int
+	// Pos is the moving rectangle at the contact, flush against the obstacle.
+	Pos Rect[S]
+	// Rest is the velocity left after the contact with its component along
+	// Normal removed, that is, the motion that slides along the surface.
+	Rest Point[S]
+}
+
+// Slid returns the position reached by sliding the rest of the way along the
+// surface hit, without checking it for further contacts.
+func (h Hit[S]) Slid() Rect[S] {
+	return h.Pos.Add(h.Rest)
+}
+
+// Sweep moves r by v and returns the first obstacle it hits, and false if it
+// hits none. Unlike checking r.Add(v) for overlaps, fast rectangles do not
+// tunnel through thin obstacles. As with Overlaps, touching is not a hit, and
+// obstacles that r already overlaps are ignored so that it can move out of
+// them. When r hits an edge and a corner at the same time, or two obstacles,
+// the horizontal normal and the lower index win.
+//
+// For integer types the contact position is exact along Normal and truncated
+// toward the start along the other axis.
+func (r Rect[S]) Sweep(v Point[S], obstacles []Rect[S]) (Hit[S], bool) {
+	hit := Hit[S]{Time: math.Inf(1), Index: -1}
+	for i, o := range obstacles {
+		if o.Empty() || r.Overlaps(o) {
+			continue
+		}
+		xin, xout := sweepAxis(r.Min.X, r.Max.X, o.Min.X, o.Max.X, v.X)
+		yin, yout := sweepAxis(r.Min.Y, r.Max.Y, o.Min.Y, o.Max.Y, v.Y)
+		in, out := max(xin, yin), min(xout, yout)
+		if in >= out || in < 0 || in >= 1 || in >= hit.Time {
+			continue
+		}
+		hit.Time, hit.Index = in, i
+		if xin >= yin {
+			hit.Normal = Point[int]{X: -sign(v.X)}
+		} else {
+			hit.Normal = Point[int]{Y: -sign(v.Y)}
+		}
+	}
+	if hit.Index < 0 {
+		return Hit[S]{}, false
+	}
+
+	o := obstacles[hit.Index]
+	var d Point[S]
+	if hit.Normal.X != 0 {
+		d.X = flush(r.Min.X, r.Max.X, o.Min.X, o.Max.X, v.X)
+		d.Y = truncS[S](float64(v.Y) * hit.Time)
+		hit.Rest = Point[S]{Y: v.Y - d.Y}
+	} else {
+		d.X = truncS[S](float64(v.X) * hit.Time)
+		d.Y = flush(r.Min.Y, r.Max.Y, o.Min.Y, o.Max.Y, v.Y)
+		hit.Rest = Point[S]{X: v.X - d.X}
+	}
+	hit.Pos = r.Add(d)
+	return hit, true
+}
+
+// sweepAxis returns the times at which [min, max) moving at speed v starts and
+// stops overlapping [omin, omax).
+func sweepAxis[S ng.Scalar](min, max, omin, omax, v S) (in, out float64) {
+	switch {
+	case v > 0:
+		return (float64(omin) - float64(max)) / float64(v), (float64(omax) - float64(min)) / float64(v)
+	case v < 0:
+		return (float64(omax) - float64(min)) / float64(v), (float64(omin) - float64(max)) / float64(v)
+	case max <= omin || omax <= min:
+		return math.Inf(1), math.Inf(-1)
+	}
+	return math.Inf(-1), math.Inf(1)
+}
+
+// flush returns the displacement that moves [min, max) at speed v against
+// [omin, omax).
+func flush[S ng.Scalar](min, max, omin, omax, v S) S {
+	if v > 0 {
+		return omin - max
+	}
+	return omax - min
+}
+
+func sign[S ng.Scalar](s S) int {
+	switch {
+	case s > 0:
+		return 1
+	case s < 0:
+		return -1
+	}
+	return 0
+}
+
+// truncS converts f to S, rounding toward zero for integer S.
+func truncS[S ng.Scalar](f float64) S {
+	if isInt[S]() {
+		return S(math.Trunc(snap(f)))
+	}
+	return S(f)
+}
+
+// MoveAndSlide moves r by v through obstacles, sliding along each surface it
+// hits, and returns the final position and the contacts in order. At most
+// maxContacts contacts are resolved, 4 if it is not positive; the motion
+// stops at the last one.
+func (r Rect[S]) MoveAndSlide(v Point[S], obstacles []Rect[S], maxContacts int) (Rect[S], []Hit[S]) {
+	if maxContacts <= 0 {
+		maxContacts = 4
+	}
+	var hits []Hit[S]
+	for len(hits) < maxContacts {
+		hit, ok := r.Sweep(v, obstacles)
+		if !ok {
+			return r.Add(v), hits
+		}
+		hits = append(hits, hit)
+		r, v = hit.Pos, hit.Rest
+		if v == (Point[S]{}) {
+			break
+		}
+	}
+	return r, hits
+}
+
+// MoveX moves r horizontally by up to dx, stopping flush against the first
+// obstacle in the way, and reports whether it ended against one. It works in
+// exact S arithmetic, so integer rectangles move pixel by pixel with no
+// rounding. Obstacles that r already overlaps are ignored.
+func (r Rect[S]) MoveX(dx S, obstacles []Rect[S]) (Rect[S], bool) {
+	d, blocked := moveAxis(r.Min.X, r.Max.X, r.Min.Y, r.Max.Y, dx, obstacles, func(o Rect[S]) (S, S, S, S) {
+		return o.Min.X, o.Max.X, o.Min.Y, o.Max.Y
+	})
+	if dx < 0 {
+		return Rect[S]{Min: Point[S]{X: r.Min.X - d, Y: r.Min.Y}, Max: Point[S]{X: r.Max.X - d, Y: r.Max.Y}}, blocked
+	}
+	return Rect[S]{Min: Point[S]{X: r.Min.X + d, Y: r.Min.Y}, Max: Point[S]{X: r.Max.X + d, Y: r.Max.Y}}, blocked
+}
+
+// MoveY is like MoveX, but moves r vertically by up to dy.
+func (r Rect[S]) MoveY(dy S, obstacles []Rect[S]) (Rect[S], bool) {
+	d, blocked := moveAxis(r.Min.Y, r.Max.Y, r.Min.X, r.Max.X, dy, obstacles, func(o Rect[S]) (S, S, S, S) {
+		return o.Min.Y, o.Max.Y, o.Min.X, o.Max.X
+	})
+	if dy < 0 {
+		return Rect[S]{Min: Point[S]{X: r.Min.X, Y: r.Min.Y - d}, Max: Point[S]{X: r.Max.X, Y: r.Max.Y - d}}, blocked
+	}
+	return Rect[S]{Min: Point[S]{X: r.Min.X, Y: r.Min.Y + d}, Max: Point[S]{X: r.Max.X, Y: r.Max.Y + d}}, blocked
+}
+
+// Move moves r by v with MoveX and then MoveY, the usual resolution order of
+// pixel-based platformers, and returns the new position and the normals of
+// the surfaces that stopped it on each axis, if any.
+func (r Rect[S]) Move(v Point[S], obstacles []Rect[S]) (Rect[S], Point[int]) {
+	var n Point[int]
+	var blocked bool
+	if r, blocked = r.MoveX(v.X, obstacles); blocked {
+		n.X = -sign(v.X)
+	}
+	if r, blocked = r.MoveY(v.Y, obstacles); blocked {
+		n.Y = -sign(v.Y)
+	}
+	return r, n
+}
+
+// moveAxis returns the distance, as a magnitude, that the span [min, max) with
+// cross span [cmin, cmax) can move by d before touching an obstacle, whose
+// spans are given by axes.
+func moveAxis[S ng.Scalar](min, max, cmin, cmax, d S, obstacles []Rect[S], axes func(Rect[S]) (S, S, S, S)) (S, bool) {
+	if d == 0 {
+		return 0, false
+	}
+	dist := d
+	if d < 0 {
+		dist = -d
+	}
+	blocked := false
+	for _, o := range obstacles {
+		omin, omax, ocmin, ocmax := axes(o)
+		if omin >= omax || ocmin >= ocmax || ocmax <= cmin || cmax <= ocmin {
+			continue
+		}
+		var gap S
+		switch {
+		case d > 0 && max <= omin:
+			gap = omin - max
+		case d < 0 && omax <= min:
+			gap = min - omax
+		default:
+			continue // behind, or already overlapping
+		}
+		if gap <= dist {
+			dist, blocked = gap, true
+		}
+	}
+	return dist, blocked
+}
@@ -0,0 +1,110 @@
+package loc_test
+
+import (
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestRect_Sweep(t *testing.T) {
+	r := loc.Xywh(0.0, 0.0, 2.0, 2.0)
+	wall := loc.Xyxy(10.0, -10.0, 11.0, 10.0) // thin enough to tunnel through
+
+	if r.Add(loc.Xy(20.0, 5.0)).Overlaps(wall) {
+		t.Fatalf("test setup: the end position should not overlap the wall")
+	}
+	hit, ok := r.Sweep(loc.Xy(20.0, 5.0), []loc.Rect[float64]{{}, wall})
+	if !ok {
+		t.Fatalf("Sweep missed the wall")
+	}
+	if hit.Index != 1 || hit.Time != 0.4 || hit.Normal != loc.Xy(-1, 0) {
+		t.Errorf("Sweep hit mismatch, got %+v", hit)
+	}
+	if want := loc.Xywh(8.0, 2.0, 2.0, 2.0); hit.Pos != want {
+		t.Errorf("Sweep Pos mismatch, want %v, got %v", want, hit.Pos)
+	}
+	if want := loc.Xy(0.0, 3.0); hit.Rest != want {
+		t.Errorf("Sweep Rest mismatch, want %v, got %v", want, hit.Rest)
+	}
+	if want := loc.Xywh(8.0, 5.0, 2.0, 2.0); hit.Slid() != want {
+		t.Errorf("Slid mismatch, want %v, got %v", want, hit.Slid())
+	}
+
+	// Sliding along the wall's face, or moving away, is not a hit.
+	if _, ok := hit.Pos.Sweep(loc.Xy(0.0, 5.0), []loc.Rect[float64]{wall}); ok {
+		t.Errorf("sliding along a surface should not hit")
+	}
+	if _, ok := hit.Pos.Sweep(loc.Xy(-5.0, 0.0), []loc.Rect[float64]{wall}); ok {
+		t.Errorf("moving away should not hit")
+	}
+	// The nearest of several obstacles wins.
+	near := loc.Xyxy(5.0, 0.0, 6.0, 1.0)
+	if hit, ok := r.Sweep(loc.Xy(20.0, 0.0), []loc.Rect[float64]{wall, near}); !ok || hit.Index != 1 {
+		t.Errorf("Sweep should hit the nearest obstacle, got %+v", hit)
+	}
+}
+
+func TestRect_Sweep_Int(t *testing.T) {
+	r := loc.Xywh(0, 0, 4, 4)
+	floor := loc.Xyxy(-100, 10, 100, 11)
+	hit, ok := r.Sweep(loc.Xy(3, 9), []loc.Rect[int]{floor})
+	if !ok || hit.Normal != loc.Xy(0, -1) {
+		t.Fatalf("Sweep mismatch, got %+v %v", hit, ok)
+	}
+	if want := loc.Xywh(2, 6, 4, 4); hit.Pos != want {
+		t.Errorf("Sweep Pos mismatch, want %v, got %v", want, hit.Pos)
+	}
+	if hit.Pos.Overlaps(floor) || hit.Rest != loc.Xy(1, 0) {
+		t.Errorf("Sweep contact mismatch, got %+v", hit)
+	}
+}
+
+func TestRect_MoveAndSlide(t *testing.T) {
+	r := loc.Xywh(0, 0, 2, 2)
+	obstacles := []loc.Rect[int]{
+		loc.Xyxy(10, -20, 12, 20), // wall to the right
+		loc.Xyxy(-20, 8, 20, 10),  // floor below
+	}
+	got, hits := r.MoveAndSlide(loc.Xy(20, 20), obstacles, 0)
+	if want := loc.Xywh(8, 6, 2, 2); got != want {
+		t.Errorf("MoveAndSlide mismatch, want %v, got %v", want, got)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("MoveAndSlide contacts mismatch, got %+v", hits)
+	}
+	for _, h := range hits {
+		for _, o := range obstacles {
+			if h.Pos.Overlaps(o) {
+				t.Errorf("contact %v overlaps %v", h.Pos, o)
+			}
+		}
+	}
+	if got, hits := r.MoveAndSlide(loc.Xy(3, 1), obstacles, 0); got != loc.Xywh(3, 1, 2, 2) || hits != nil {
+		t.Errorf("free MoveAndSlide mismatch, got %v %v", got, hits)
+	}
+	if _, hits := r.MoveAndSlide(loc.Xy(20, 20), obstacles, 1); len(hits) != 1 {
+		t.Errorf("maxContacts not honored, got %d contacts", len(hits))
+	}
+}
+
+func TestRect_Move(t *testing.T) {
+	r := loc.Xywh(0, 0, 4, 4)
+	obstacles := []loc.Rect[int]{
+		loc.Xyxy(9, 0, 10, 100),  // one pixel wide wall
+		loc.Xyxy(0, 12, 100, 13), // floor
+	}
+	got, n := r.Move(loc.Xy(50, 50), obstacles)
+	if want := loc.Xywh(5, 8, 4, 4); got != want || n != loc.Xy(-1, -1) {
+		t.Errorf("Move mismatch, want %v, got %v %v", want, got, n)
+	}
+	// Already flush: no movement, still blocked.
+	if got, blocked := got.MoveX(1, obstacles); !blocked || got != loc.Xywh(5, 8, 4, 4) {
+		t.Errorf("flush MoveX mismatch, got %v %v", got, blocked)
+	}
+	if got, blocked := got.MoveX(-3, obstacles); blocked || got != loc.Xywh(2, 8, 4, 4) {
+		t.Errorf("free MoveX mismatch, got %v %v", got, blocked)
+	}
+	if got, blocked := got.MoveY(0, obstacles); blocked || got != loc.Xywh(5, 8, 4, 4) {
+		t.Errorf("zero MoveY mismatch, got %v %v", got, blocked)
+	}
+}
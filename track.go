@@ -0,0 +1,270 @@
+package loc
+
+import (
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A TrackKind determines how a Track is sized.
+type TrackKind int
+
+const (
+	// TrackFixed tracks have an absolute size.
+	TrackFixed TrackKind = iota
+	// TrackFr tracks share the space left by the other tracks in
+	// proportion to their weights, like the CSS fr unit.
+	TrackFr
+	// TrackPercent tracks take a percentage of the available length.
+	TrackPercent
+	// TrackAuto tracks are sized to their content. They behave like fixed
+	// tracks whose Size is filled in by a layout such as Grid.
+	TrackAuto
+)
+
+// A Track describes the size of one cell of a split along an axis.
+// The computed size is clamped to [Min, Max]. A zero Max means no upper bound.
+type Track[S ng.Scalar] struct {
+	Kind     TrackKind
+	Size     S       // used by TrackFixed and TrackAuto
+	Value    float64 // weight for TrackFr, percentage for TrackPercent
+	Min, Max S
+}
+
+// Fixed returns a track of the given absolute size.
+func Fixed[S ng.Scalar](size S) Track[S] {
+	return Track[S]{Kind: TrackFixed, Size: size}
+}
+
+// Fr returns a track that takes a share of the free space with the given weight.
+func Fr[S ng.Scalar](weight float64) Track[S] {
+	return Track[S]{Kind: TrackFr, Value: weight}
+}
+
+// Percent returns a track that takes p percent of the available length.
+func Percent[S ng.Scalar](p float64) Track[S] {
+	return Track[S]{Kind: TrackPercent, Value: p}
+}
+
+// Auto returns a track sized to its content.
+func Auto[S ng.Scalar]() Track[S] {
+	return Track[S]{Kind: TrackAuto}
+}
+
+// Clamp returns t with its size limited to [min, max].
+// A zero max means no upper bound.
+func (t Track[S]) Clamp(min, max S) Track[S] {
+	t.Min, t.Max = min, max
+	return t
+}
+
+func (t Track[S]) clamp(s S) S {
+	if t.Max > 0 && s > t.Max {
+		s = t.Max
+	}
+	if s < t.Min {
+		s = t.Min
+	}
+	return s
+}
+
+func (t Track[S]) clampFloat(f float64) float64 {
+	if t.Max > 0 && f > float64(t.Max) {
+		f = float64(t.Max)
+	}
+	if f < float64(t.Min) {
+		f = float64(t.Min)
+	}
+	return f
+}
+
+// isInt reports whether S is an integer type.
+func isInt[S ng.Scalar]() bool {
+	var one S = 1
+	return one/2 == 0
+}
+
+// available returns the length left for n tracks after removing n-1 gaps.
+// It is never negative.
+func available[S ng.Scalar](length S, n int, gap S) S {
+	if n <= 1 || gap <= 0 {
+		return max(length, 0)
+	}
+	gaps := S(n-1) * gap
+	if gaps >= length {
+		return 0
+	}
+	return length - gaps
+}
+
+// TrackSizes computes the sizes of tracks sharing the given length.
+// Fixed and percent tracks are sized first, then fr tracks share what is left.
+// If a fr track would violate its Min or Max it is frozen at that bound and
+// the others are resolved again. For integer S, the remainder of the fr
+// division is handed out one unit at a time to the tracks with the largest
+// fractional parts, breaking ties by index, so the result is deterministic.
+func TrackSizes[S ng.Scalar](length S, tracks []Track[S]) []S {
+	if len(tracks) == 0 {
+		return nil
+	}
+	length = max(length, 0)
+	sizes := make([]S, len(tracks))
+	var used S
+	var flex []int
+	for i, t := range tracks {
+		switch t.Kind {
+		case TrackFixed, TrackAuto:
+			sizes[i] = t.clamp(t.Size)
+		case TrackPercent:
+			sizes[i] = t.clamp(rel(length, t.Value/100))
+		case TrackFr:
+			flex = append(flex, i)
+			continue
+		}
+		used += sizes[i]
+	}
+	if len(flex) == 0 {
+		return sizes
+	}
+
+	free := 0.0
+	if used < length {
+		free = float64(length - used)
+	}
+	base := make([]float64, len(flex))
+	weights := make([]float64, len(flex))
+	for j, i := range flex {
+		weights[j] = tracks[i].Value
+	}
+	shares := distribute(base, free, weights, func(j int, f float64) float64 {
+		return tracks[flex[j]].clampFloat(f)
+	})
+	for j, s := range apportion[S](shares) {
+		sizes[flex[j]] = s
+	}
+	return sizes
+}
+
+// distribute resolves flexible lengths in the manner of CSS flexbox. Each
+// item starts at base[i] and receives a share of free in proportion to
+// weights[i]; free may be negative. Items whose size would violate clamp are
+// frozen at the clamped size and the rest are resolved again. Items with a
+// non-positive weight keep their clamped base size.
+func distribute(base []float64, free float64, weights []float64, clamp func(i int, f float64) float64) []float64 {
+	sizes := make([]float64, len(base))
+	frozen := make([]bool, len(base))
+	total := free
+	for i, b := range base {
+		total += b
+		if weights[i] <= 0 {
+			sizes[i] = clamp(i, b)
+			frozen[i] = true
+		}
+	}
+	for {
+		remaining, weight := total, 0.0
+		for i := range base {
+			if frozen[i] {
+				remaining -= sizes[i]
+			} else {
+				remaining -= base[i]
+				weight += weights[i]
+			}
+		}
+		if weight == 0 {
+			return sizes
+		}
+		var violation float64
+		for i := range base {
+			if !frozen[i] {
+				sizes[i] = base[i] + remaining*weights[i]/weight
+				violation += clamp(i, sizes[i]) - sizes[i]
+			}
+		}
+		done := true
+		for i := range base {
+			if frozen[i] {
+				continue
+			}
+			c := clamp(i, sizes[i])
+			if c == sizes[i] ||
+				violation > 0 && c < sizes[i] ||
+				violation < 0 && c > sizes[i] {
+				continue
+			}
+			sizes[i] = c
+			frozen[i] = true
+			done = false
+		}
+		if done {
+			return sizes
+		}
+	}
+}
+
+// apportion converts sizes to S. For integer S every size is floored and the
+// units lost to flooring are handed back one at a time to the sizes with the
+// largest fractional parts, breaking ties by index.
+func apportion[S ng.Scalar](sizes []float64) []S {
+	out := make([]S, len(sizes))
+	if !isInt[S]() {
+		for i, f := range sizes {
+			out[i] = S(f)
+		}
+		return out
+	}
+	var sum, floored float64
+	var open []int
+	for i, f := range sizes {
+		fl := math.Floor(f)
+		out[i] = S(fl)
+		sum += f
+		floored += fl
+		if f > fl {
+			open = append(open, i)
+		}
+	}
+	slices.SortStableFunc(open, func(a, b int) int {
+		fa := sizes[a] - math.Floor(sizes[a])
+		fb := sizes[b] - math.Floor(sizes[b])
+		switch {
+		case fa > fb:
+			return -1
+		case fa < fb:
+			return 1
+		}
+		return 0
+	})
+	left := max(int(math.Round(sum-floored)), 0)
+	for _, i := range open[:min(left, len(open))] {
+		out[i]++
+	}
+	return out
+}
+
+// TracksX splits r into columns sized by tracks, separated by gap.
+// Tracks that do not fit extend past r.Max.X.
+func (r Rect[S]) TracksX(gap S, tracks ...Track[S]) []Rect[S] {
+	sizes := TrackSizes(available(r.Dx(), len(tracks), gap), tracks)
+	rects := make([]Rect[S], len(sizes))
+	x := r.Min.X
+	for i, w := range sizes {
+		rects[i] = Xywh(x, r.Min.Y, w, r.Dy())
+		x += w + gap
+	}
+	return rects
+}
+
+// TracksY splits r into rows sized by tracks, separated by gap.
+// Tracks that do not fit extend past r.Max.Y.
+func (r Rect[S]) TracksY(gap S, tracks ...Track[S]) []Rect[S] {
+	sizes := TrackSizes(available(r.Dy(), len(tracks), gap), tracks)
+	rects := make([]Rect[S], len(sizes))
+	y := r.Min.Y
+	for i, h := range sizes {
+		rects[i] = Xywh(r.Min.X, y, r.Dx(), h)
+		y += h + gap
+	}
+	return rects
+}
@@ -0,0 +1,93 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestTrackSizes_Mixed(t *testing.T) {
+	got := loc.TrackSizes(100, []loc.Track[int]{
+		loc.Fixed(20),
+		loc.Percent[int](30),
+		loc.Fr[int](1),
+		loc.Fr[int](2),
+	})
+	want := []int{20, 30, 17, 33}
+	if !slices.Equal(want, got) {
+		t.Errorf("TrackSizes mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestTrackSizes_Remainder(t *testing.T) {
+	got := loc.TrackSizes(100, []loc.Track[int]{
+		loc.Fr[int](1),
+		loc.Fr[int](1),
+		loc.Fr[int](1),
+	})
+	want := []int{34, 33, 33}
+	if !slices.Equal(want, got) {
+		t.Errorf("TrackSizes remainder mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestTrackSizes_Clamp(t *testing.T) {
+	got := loc.TrackSizes(100, []loc.Track[int]{
+		loc.Fr[int](1).Clamp(0, 10),
+		loc.Fr[int](1),
+		loc.Fr[int](1).Clamp(60, 0),
+	})
+	want := []int{10, 30, 60}
+	if !slices.Equal(want, got) {
+		t.Errorf("TrackSizes clamp mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestTrackSizes_Overflow(t *testing.T) {
+	got := loc.TrackSizes(50, []loc.Track[int]{
+		loc.Fixed(40),
+		loc.Fixed(30),
+		loc.Fr[int](1).Clamp(5, 0),
+	})
+	want := []int{40, 30, 5}
+	if !slices.Equal(want, got) {
+		t.Errorf("TrackSizes overflow mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestTrackSizes_Float(t *testing.T) {
+	got := loc.TrackSizes(90.0, []loc.Track[float64]{
+		loc.Fr[float64](1),
+		loc.Fr[float64](2),
+	})
+	want := []float64{30, 60}
+	if !slices.Equal(want, got) {
+		t.Errorf("TrackSizes float mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestRect_TracksY_Gap(t *testing.T) {
+	rect := loc.Xyxy(0, 0, 50, 100)
+	got := rect.TracksY(5, loc.Fixed(20), loc.Fr[int](1), loc.Fixed(10))
+	want := []loc.Rect[int]{
+		loc.Xyxy(0, 0, 50, 20),
+		loc.Xyxy(0, 25, 50, 85),
+		loc.Xyxy(0, 90, 50, 100),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("TracksY mismatch, want %v, got %v", want, got)
+	}
+}
+
+func TestRect_TracksX_Unsigned(t *testing.T) {
+	rect := loc.Xyxy[uint](0, 0, 10, 10)
+	got := rect.TracksX(20, loc.Fr[uint](1), loc.Fr[uint](1))
+	want := []loc.Rect[uint]{
+		loc.Xyxy[uint](0, 0, 0, 10),
+		loc.Xyxy[uint](20, 0, 20, 10),
+	}
+	if !slices.Equal(want, got) {
+		t.Errorf("TracksX unsigned mismatch, want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,81 @@
+package loc
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// FlipY converts p between Y-down and Y-up coordinates, where height is the
+// Y coordinate of the top edge in Y-up terms (usually the screen height).
+// Applying FlipY twice with the same height returns p.
+func (p Point[S]) FlipY(height S) Point[S] {
+	return Point[S]{X: p.X, Y: height - p.Y}
+}
+
+// FlipY converts r between Y-down and Y-up coordinates like Point.FlipY.
+// The result is well-formed if r is.
+func (r Rect[S]) FlipY(height S) Rect[S] {
+	return Rect[S]{
+		Min: Point[S]{X: r.Min.X, Y: height - r.Max.Y},
+		Max: Point[S]{X: r.Max.X, Y: height - r.Min.Y},
+	}
+}
+
+// A YUpRect is a rectangle in a coordinate system where Y increases upward,
+// as in OpenGL or physics code, so its top edge is Max.Y. Its methods take the
+// same relative positions as their Y-down counterparts, with ry = 0 meaning
+// the top, so call sites need no manual flipping.
+type YUpRect[S ng.Scalar] struct {
+	Rect Rect[S]
+}
+
+// YUp returns r viewed in Y-up coordinates.
+func (r Rect[S]) YUp() YUpRect[S] {
+	return YUpRect[S]{Rect: r}
+}
+
+// Anchor returns the point at the relative position (rx, ry) of u, where
+// 0, 0 is the top-left corner and 1, 1 the bottom-right one.
+func (u YUpRect[S]) Anchor(rx, ry float64) Point[S] {
+	return u.Rect.Anchor(rx, 1-ry)
+}
+
+// Center returns the center point of u.
+func (u YUpRect[S]) Center() Point[S] {
+	return u.Rect.Center()
+}
+
+// Align returns a rectangle with the size of u where p is at the relative
+// position (rx, ry), with ry = 0 meaning the top.
+func (u YUpRect[S]) Align(p Point[S], rx, ry float64) Rect[S] {
+	return p.Align(u.Rect, rx, 1-ry)
+}
+
+// Within returns a rectangle with the size of u placed within s at the
+// relative position (rx, ry), with ry = 0 meaning the top.
+func (u YUpRect[S]) Within(s Rect[S], rx, ry float64) Rect[S] {
+	return u.Rect.Within(s, rx, 1-ry)
+}
+
+// CutTop cuts u into two rectangles at h below the top edge. It returns the
+// top part (got) and the bottom part (rest), clamped as with CutY.
+func (u YUpRect[S]) CutTop(h S) (got, rest Rect[S]) {
+	r := u.Rect
+	h = min(max(h, 0), r.Dy())
+	rest, got = r.CutY(r.Dy() - h)
+	return got, rest
+}
+
+// CutBottom cuts u into two rectangles at h above the bottom edge. It returns
+// the bottom part (got) and the top part (rest).
+func (u YUpRect[S]) CutBottom(h S) (got, rest Rect[S]) {
+	return u.Rect.CutY(h)
+}
+
+// SplitY splits u into n rectangles like Rect.SplitY, ordered top to bottom.
+func (u YUpRect[S]) SplitY(n int, gap S) []Rect[S] {
+	rects := u.Rect.SplitY(n, gap)
+	slices.Reverse(rects)
+	return rects
+}
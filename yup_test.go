@@ -0,0 +1,50 @@
+package loc_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eihigh/loc"
+)
+
+func TestRect_FlipY(t *testing.T) {
+	r := loc.Xyxy(10, 20, 30, 50)
+	got := r.FlipY(100)
+	if want := loc.Xyxy(10, 50, 30, 80); got != want {
+		t.Errorf("FlipY mismatch, want %v, got %v", want, got)
+	}
+	if back := got.FlipY(100); back != r {
+		t.Errorf("FlipY twice mismatch, want %v, got %v", r, back)
+	}
+	if p := loc.Xy(3, 4).FlipY(10); p != loc.Xy(3, 6) {
+		t.Errorf("Point.FlipY mismatch, got %v", p)
+	}
+}
+
+func TestYUpRect(t *testing.T) {
+	screen := loc.Xyxy(0, 0, 800, 600).YUp()
+	if got, want := screen.Anchor(0, 0), loc.Xy(0, 600); got != want {
+		t.Errorf("Anchor(0, 0) mismatch, want %v, got %v", want, got)
+	}
+	box := loc.Xyxy(0, 0, 100, 50).YUp()
+	if got, want := box.Within(screen.Rect, 1, 0), loc.Xyxy(700, 550, 800, 600); got != want {
+		t.Errorf("Within top right mismatch, want %v, got %v", want, got)
+	}
+	if got, want := box.Align(loc.Xy(400, 300), 0.5, 0), loc.Xyxy(350, 250, 450, 300); got != want {
+		t.Errorf("Align top center mismatch, want %v, got %v", want, got)
+	}
+
+	top, rest := screen.CutTop(40)
+	if top != loc.Xyxy(0, 560, 800, 600) || rest != loc.Xyxy(0, 0, 800, 560) {
+		t.Errorf("CutTop mismatch, got %v %v", top, rest)
+	}
+	bottom, rest := screen.CutBottom(40)
+	if bottom != loc.Xyxy(0, 0, 800, 40) || rest != loc.Xyxy(0, 40, 800, 600) {
+		t.Errorf("CutBottom mismatch, got %v %v", bottom, rest)
+	}
+	rows := screen.SplitY(3, 0)
+	want := []loc.Rect[int]{loc.Xyxy(0, 400, 800, 600), loc.Xyxy(0, 200, 800, 400), loc.Xyxy(0, 0, 800, 200)}
+	if !slices.Equal(want, rows) {
+		t.Errorf("SplitY mismatch, want %v, got %v", want, rows)
+	}
+}